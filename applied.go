@@ -0,0 +1,183 @@
+package ticker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AppliedConfig is a snapshot of the configuration that a set of options produces,
+// for inspection in logs and tests.
+//
+// It mirrors the settings that are plain values. Callbacks, channels and other
+// behaviors are not described, except whether a backoff is set. A zero value of a
+// duration or a time means that the setting is not used.
+type AppliedConfig struct {
+	Immediate bool
+	Burst     int
+
+	// Limit is the maximum number of executions, or negative if unlimited.
+	Limit int
+
+	Jitter          float64
+	InitialJitter   time.Duration
+	Backoff         bool
+	Timeout         time.Duration
+	MaxDuration     time.Duration
+	ExecBudget      time.Duration
+	EndTime         time.Time
+	StartDelay      time.Duration
+	GracePeriod     time.Duration
+	ShutdownTimeout time.Duration
+	FirstInterval   time.Duration
+	MinInterval     time.Duration
+	Align           bool
+	Epoch           time.Time
+	AlignTolerance  time.Duration
+	WallClock       bool
+	FixedDelay      bool
+	CatchUp         bool
+	MaxCatchUp      int
+	SkipIfRunning   bool
+	Async           bool
+	LockOSThread    bool
+	Spin            bool
+	FinalTick       bool
+	RepeatLast      bool
+	Concurrency     int
+
+	StopOnError          bool
+	MaxErrors            int
+	MaxConsecutiveErrors int
+	ReturnLastError      bool
+	FailFastFirst        bool
+	PanicAsError         bool
+	TickError            bool
+	ContextErrorAsNil    bool
+	Health               int
+
+	Name        string
+	TraceRegion string
+}
+
+// Options returns the configuration that options produce, applied in order as by Run.
+//
+// Options does not validate the configuration; use Validate for that.
+func Options(options ...Option) AppliedConfig {
+	return applyOptions(options).applied()
+}
+
+// applied returns the snapshot of c.
+func (c *config) applied() AppliedConfig {
+	a := AppliedConfig{
+		Immediate:            c.Burst > 0,
+		Burst:                c.Burst,
+		Limit:                c.Limit,
+		Jitter:               c.Jitter,
+		InitialJitter:        c.InitialJitter,
+		Backoff:              c.Backoff != nil || c.JitteredBackoff != nil || c.ErrorBackoff != 0,
+		Timeout:              c.Timeout,
+		MaxDuration:          c.MaxDuration,
+		ExecBudget:           c.ExecBudget,
+		EndTime:              c.EndTime,
+		StartDelay:           c.StartDelay,
+		GracePeriod:          c.GracePeriod,
+		ShutdownTimeout:      c.Shutdown,
+		Align:                c.Align,
+		Epoch:                c.Epoch,
+		AlignTolerance:       c.AlignTol,
+		WallClock:            c.WallClock,
+		FixedDelay:           c.FixedDelay,
+		CatchUp:              c.CatchUp,
+		MaxCatchUp:           c.MaxCatchUp,
+		SkipIfRunning:        c.SkipIfRunning,
+		Async:                c.Async,
+		LockOSThread:         c.LockOSThread,
+		Spin:                 c.Spin,
+		FinalTick:            c.FinalTick,
+		RepeatLast:           c.RepeatLast,
+		Concurrency:          c.Concurrency,
+		StopOnError:          c.StopOnError,
+		MaxErrors:            c.MaxErrors,
+		MaxConsecutiveErrors: c.MaxConsErrors,
+		ReturnLastError:      c.ReturnLast,
+		FailFastFirst:        c.FailFastFirst,
+		PanicAsError:         c.PanicAsError,
+		TickError:            c.TickError,
+		ContextErrorAsNil:    c.CtxErrAsNil,
+		Health:               c.Health,
+		Name:                 c.Name,
+		TraceRegion:          c.TraceRegion,
+	}
+	if c.FirstInterval != nil {
+		a.FirstInterval = *c.FirstInterval
+	}
+	if c.MinInterval != nil {
+		a.MinInterval = *c.MinInterval
+	}
+	return a
+}
+
+// String returns the settings that differ from the defaults, such as
+// "{Immediate:true Limit:3}", or "{}" if there is none.
+func (a AppliedConfig) String() string {
+	d := applyOptions(nil).applied()
+	var fields []string
+	set := func(name string, v any) {
+		fields = append(fields, fmt.Sprintf("%s:%v", name, v))
+	}
+	add := func(name string, v, def any) {
+		if v != def {
+			set(name, v)
+		}
+	}
+	add("Immediate", a.Immediate, d.Immediate)
+	add("Burst", a.Burst, d.Burst)
+	add("Limit", a.Limit, d.Limit)
+	add("Jitter", a.Jitter, d.Jitter)
+	add("InitialJitter", a.InitialJitter, d.InitialJitter)
+	add("Backoff", a.Backoff, d.Backoff)
+	add("Timeout", a.Timeout, d.Timeout)
+	add("MaxDuration", a.MaxDuration, d.MaxDuration)
+	add("ExecBudget", a.ExecBudget, d.ExecBudget)
+	if !a.EndTime.IsZero() {
+		set("EndTime", a.EndTime.Format(time.RFC3339Nano))
+	}
+	add("StartDelay", a.StartDelay, d.StartDelay)
+	add("GracePeriod", a.GracePeriod, d.GracePeriod)
+	add("ShutdownTimeout", a.ShutdownTimeout, d.ShutdownTimeout)
+	add("FirstInterval", a.FirstInterval, d.FirstInterval)
+	add("MinInterval", a.MinInterval, d.MinInterval)
+	add("Align", a.Align, d.Align)
+	if !a.Epoch.IsZero() {
+		set("Epoch", a.Epoch.Format(time.RFC3339Nano))
+	}
+	add("AlignTolerance", a.AlignTolerance, d.AlignTolerance)
+	add("WallClock", a.WallClock, d.WallClock)
+	add("FixedDelay", a.FixedDelay, d.FixedDelay)
+	add("CatchUp", a.CatchUp, d.CatchUp)
+	add("MaxCatchUp", a.MaxCatchUp, d.MaxCatchUp)
+	add("SkipIfRunning", a.SkipIfRunning, d.SkipIfRunning)
+	add("Async", a.Async, d.Async)
+	add("LockOSThread", a.LockOSThread, d.LockOSThread)
+	add("Spin", a.Spin, d.Spin)
+	add("FinalTick", a.FinalTick, d.FinalTick)
+	add("RepeatLast", a.RepeatLast, d.RepeatLast)
+	add("Concurrency", a.Concurrency, d.Concurrency)
+	add("StopOnError", a.StopOnError, d.StopOnError)
+	add("MaxErrors", a.MaxErrors, d.MaxErrors)
+	add("MaxConsecutiveErrors", a.MaxConsecutiveErrors, d.MaxConsecutiveErrors)
+	add("ReturnLastError", a.ReturnLastError, d.ReturnLastError)
+	add("FailFastFirst", a.FailFastFirst, d.FailFastFirst)
+	add("PanicAsError", a.PanicAsError, d.PanicAsError)
+	add("TickError", a.TickError, d.TickError)
+	add("ContextErrorAsNil", a.ContextErrorAsNil, d.ContextErrorAsNil)
+	add("Health", a.Health, d.Health)
+	if a.Name != "" {
+		set("Name", fmt.Sprintf("%q", a.Name))
+	}
+	if a.TraceRegion != "" {
+		set("TraceRegion", fmt.Sprintf("%q", a.TraceRegion))
+	}
+	return "{" + strings.Join(fields, " ") + "}"
+}
@@ -0,0 +1,115 @@
+package ticker_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+func ExampleOptions() {
+	options := []ticker.Option{
+		ticker.WithImmediate(true),
+		ticker.WithLimit(3),
+		ticker.WithTimeout(time.Second),
+	}
+	fmt.Println(ticker.Options(options...))
+	// Output:
+	// {Immediate:true Burst:1 Limit:3 Timeout:1s}
+}
+
+// TestOptions tests that Options reflects the options applied in order
+func TestOptions(t *testing.T) {
+	if got := ticker.Options().String(); got != "{}" {
+		t.Errorf("expected {} for the defaults, got %s", got)
+	}
+
+	a := ticker.Options(
+		ticker.WithLimit(3),
+		ticker.WithLimit(5),
+		ticker.WithStopOnError(false),
+		ticker.WithFirstInterval(time.Minute),
+		ticker.WithBackoff(time.Second, time.Minute, 2),
+		ticker.WithName("poller"),
+	)
+	if a.Limit != 5 {
+		t.Errorf("expected the last limit to win, got %d", a.Limit)
+	}
+	if a.Immediate || a.StopOnError || !a.Backoff || a.FirstInterval != time.Minute || a.Name != "poller" {
+		t.Errorf("unexpected configuration %+v", a)
+	}
+	want := `{Limit:5 Backoff:true FirstInterval:1m0s StopOnError:false Name:"poller"}`
+	if got := a.String(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if a := ticker.Options(); a.Limit >= 0 || !a.StopOnError {
+		t.Errorf("expected no limit and stop on error by default, got %+v", a)
+	}
+}
+
+// TestOptions_Fields tests that AppliedConfig mirrors every plain setting of the
+// configuration and that String reports each of its fields
+func TestOptions_Fields(t *testing.T) {
+	renamed := map[string]string{
+		"AlignTol":      "AlignTolerance",
+		"MaxConsErrors": "MaxConsecutiveErrors",
+		"ReturnLast":    "ReturnLastError",
+		"Shutdown":      "ShutdownTimeout",
+		"CtxErrAsNil":   "ContextErrorAsNil",
+		"ErrorBackoff":  "Backoff",
+		"Attempts":      "", // the state of WithJitteredBackoff, not a setting
+	}
+	applied := reflect.TypeOf(ticker.AppliedConfig{})
+	config := reflect.TypeOf((*ticker.InternalConfig)(nil)).Elem()
+	for i := 0; i < config.NumField(); i++ {
+		f := config.Field(i)
+		switch f.Type.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64, reflect.String:
+		default:
+			if f.Type != reflect.TypeOf(time.Time{}) {
+				continue
+			}
+		}
+		name := f.Name
+		if r, ok := renamed[name]; ok {
+			if r == "" {
+				continue
+			}
+			name = r
+		}
+		if _, ok := applied.FieldByName(name); !ok {
+			t.Errorf("config.%s is not in AppliedConfig", f.Name)
+		}
+	}
+
+	d := ticker.Options()
+	for i := 0; i < applied.NumField(); i++ {
+		a := ticker.Options()
+		f := reflect.ValueOf(&a).Elem().Field(i)
+		def := reflect.ValueOf(d).Field(i)
+		switch v := f.Addr().Interface().(type) {
+		case *bool:
+			*v = !def.Bool()
+		case *int:
+			*v = int(def.Int()) + 1
+		case *float64:
+			*v = def.Float() + 0.5
+		case *time.Duration:
+			*v = time.Duration(def.Int()) + time.Second
+		case *time.Time:
+			*v = time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+		case *string:
+			*v = "x"
+		default:
+			t.Fatalf("unexpected type %s of AppliedConfig.%s", f.Type(), applied.Field(i).Name)
+		}
+		if name := applied.Field(i).Name; !strings.Contains(a.String(), " "+name+":") &&
+			!strings.Contains(a.String(), "{"+name+":") {
+			t.Errorf("expected %s in %s", name, a)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package ticker
+
+import "context"
+
+// WithAsync returns an Option to set whether each execution runs in its own goroutine.
+//
+// By default, the ticker waits for each execution to complete before it considers the
+// next tick. When enabled, every tick, including the immediate ones of WithImmediate and
+// WithBurst, launches an execution without waiting, so executions overlap when the task
+// takes longer than the interval, and no tick is dropped. Use WithSemaphore to bound the
+// overlap.
+//
+// Executions start in the order of the ticks, but may complete in any order. Their
+// errors are reported as usual to WithErrorChannel, WithObserver, WithAfterTick and
+// WithLogger, and an error that stops the ticker stops it as soon as it is reported,
+// in which case Run returns it. The callbacks may then be called concurrently, and the
+// task must be safe for concurrent use.
+//
+// Run returns when the ticker stops, without waiting for the executions in flight,
+// which keep running with the context of the ticker and whose errors are then only
+// reported to the callbacks, unless the limit is reached with WithOnLimitReached. Use
+// Start and Handle.Drain to wait for them on shutdown.
+// WithAsync conflicts with WithSkipIfRunning, and has no effect with WithSpin.
+func WithAsync(v bool) Option {
+	return async(v)
+}
+
+type async bool
+
+func (o async) apply(c *config) {
+	c.Async = bool(o)
+}
+
+// launch executes the task in a new goroutine for WithAsync. An error that stops the
+// ticker is sent on s.asyncErr, unless another one is already pending.
+func (s *session) launch(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.exec(ctx); err != nil {
+			select {
+			case s.asyncErr <- err:
+			default:
+			}
+		}
+	}()
+}
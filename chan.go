@@ -0,0 +1,58 @@
+package ticker
+
+import (
+	"context"
+	"time"
+)
+
+// Chan returns a channel that delivers the time of each tick of a ticker, and a
+// channel that delivers the error that stops it, for use in a select loop:
+//
+//	ticks, errc := ticker.Chan(ctx, time.Second, ticker.WithLimit(10))
+//	for t := range ticks {
+//		fmt.Println(t)
+//	}
+//	if err := <-errc; err != nil {
+//		log.Fatal(err)
+//	}
+//
+// It is like time.Tick, with the options of Run and a clean shutdown: the ticker stops,
+// and its goroutine exits, when the context is done or the ticker stops for any other
+// reason, such as WithLimit. Both channels are then closed exactly once, after the
+// error, if any, has been sent on the error channel, which is buffered. A nil error is
+// not sent, so a receive from the error channel yields nil on a clean stop. If the
+// arguments are invalid, the error is sent and both channels are closed right away.
+//
+// The tick channel is unbuffered. Like time.Ticker, the ticks that fire while the
+// receiver is not ready are dropped, unless WithCatchUp is set. The receiver must keep
+// receiving until the channel is closed, or cancel the context. Options apply as for
+// Run; WithAsync, WithSkipIfRunning and WithFinalTick have no effect.
+func Chan(ctx context.Context, d time.Duration, options ...Option) (<-chan time.Time, <-chan error) {
+	ticks := make(chan time.Time)
+	errc := make(chan error, 1)
+	var s *session
+	task := func(ctx context.Context) error {
+		select {
+		case ticks <- s.c.Clock.Now():
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s, err := newSession(task, d, options)
+	if err != nil {
+		errc <- err
+		close(ticks)
+		close(errc)
+		return ticks, errc
+	}
+	s.c.Async, s.c.SkipIfRunning, s.c.FinalTick = false, false, false
+	go func() {
+		defer close(errc)
+		defer close(ticks)
+		if err := s.run(ctx); err != nil {
+			errc <- err
+		}
+	}()
+	return ticks, errc
+}
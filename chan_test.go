@@ -0,0 +1,43 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestChan tests that Chan delivers the ticks and then the error, and closes both channels
+func TestChan(t *testing.T) {
+	ticks, errc := ticker.Chan(context.Background(), time.Millisecond, ticker.WithImmediate(true), ticker.WithLimit(3))
+	count := 0
+	for range ticks {
+		count++
+	}
+	if err := <-errc; err != nil || count != 3 {
+		t.Errorf("expected 3 ticks without error, got %d and %v", count, err)
+	}
+	if _, ok := <-errc; ok {
+		t.Errorf("expected the error channel to be closed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks, errc = ticker.Chan(ctx, time.Millisecond)
+	<-ticks
+	cancel()
+	for range ticks {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	ticks, errc = ticker.Chan(context.Background(), 0)
+	if _, ok := <-ticks; ok {
+		t.Errorf("expected the tick channel to be closed")
+	}
+	if err := <-errc; !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected ErrNonPositiveInterval, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package ticker
+
+import "time"
+
+// Clock provides the current time, timers and tickers to a ticker.
+//
+// The default Clock uses the time package. A fake Clock, such as the one provided by the
+// clocktest package, can be set with WithClock to control time deterministically in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTicker returns a new Ticker that sends the current time every d.
+	NewTicker(d time.Duration) Ticker
+
+	// NewTimer returns a new Timer that sends the current time after d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker is the interface of a ticker created by a Clock. It mirrors time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Reset stops the ticker and resets its period to d.
+	Reset(d time.Duration)
+
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// Timer is the interface of a timer created by a Clock. It mirrors time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Reset changes the timer to expire after d.
+	// It reports whether the timer had been active.
+	Reset(d time.Duration) bool
+
+	// Stop prevents the timer from firing.
+	// It reports whether the call stops the timer.
+	Stop() bool
+}
+
+// RealClock returns the Clock backed by the time package.
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
@@ -0,0 +1,209 @@
+// Package clocktest provides a fake ticker.Clock for deterministic tests.
+//
+// The fake Clock only moves when Advance or Set is called. Timers and tickers created
+// from it fire synchronously during those calls, in the order of their expiry.
+// BlockUntil helps to synchronize with code that waits on the Clock in another goroutine:
+//
+//	clock := clocktest.NewClock(time.Now())
+//	go task.Run(ctx, time.Second, ticker.WithClock(clock))
+//	clock.BlockUntil(1)          // wait until the ticker waits for its first tick
+//	clock.Advance(time.Second)   // fire the first tick
+package clocktest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// Clock is a fake ticker.Clock whose time is controlled manually.
+//
+// All methods of Clock are safe for concurrent use.
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+var _ ticker.Clock = (*Clock)(nil)
+
+// NewClock returns a new Clock set to now.
+func NewClock(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current time of the Clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a new ticker.Ticker that sends the time every d.
+// It panics if d is not positive, like time.NewTicker.
+func (c *Clock) NewTicker(d time.Duration) ticker.Ticker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	w := &waiter{clock: c, ch: make(chan time.Time, 1), period: d}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.start(w, d)
+	return fakeTicker{w}
+}
+
+// NewTimer returns a new ticker.Timer that sends the time after d.
+func (c *Clock) NewTimer(d time.Duration) ticker.Timer {
+	w := &waiter{clock: c, ch: make(chan time.Time, 1)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.start(w, d)
+	return fakeTimer{w}
+}
+
+// Advance moves the Clock forward by d, firing the timers and tickers that expire
+// in the meantime.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	c.Set(target)
+}
+
+// Set moves the Clock to t, firing the timers and tickers that expire in the meantime.
+// Moving the Clock backwards does not fire anything.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for {
+		sort.SliceStable(c.waiters, func(i, j int) bool {
+			return c.waiters[i].when.Before(c.waiters[j].when)
+		})
+		if len(c.waiters) == 0 || c.waiters[0].when.After(t) {
+			break
+		}
+		w := c.waiters[0]
+		if w.when.After(c.now) {
+			c.now = w.when
+		}
+		c.remove(w)
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+		if w.period > 0 {
+			c.start(w, w.period)
+		}
+	}
+	c.now = t
+	c.cond.Broadcast()
+}
+
+// BlockUntil blocks until at least n timers and tickers are waiting to fire.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// Waiters returns the number of timers and tickers waiting to fire.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// start schedules w to fire after d. A timer with a non-positive d fires immediately.
+// c.mu must be held.
+func (c *Clock) start(w *waiter, d time.Duration) {
+	c.remove(w)
+	if d <= 0 && w.period == 0 {
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+		return
+	}
+	w.when = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+}
+
+// remove unschedules w and reports whether it was scheduled. c.mu must be held.
+func (c *Clock) remove(w *waiter) bool {
+	for i, v := range c.waiters {
+		if v == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+// waiter is a timer or, if period is positive, a ticker of a Clock.
+type waiter struct {
+	clock  *Clock
+	ch     chan time.Time
+	when   time.Time
+	period time.Duration
+}
+
+func (w *waiter) C() <-chan time.Time {
+	return w.ch
+}
+
+func (w *waiter) reset(d time.Duration) bool {
+	c := w.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.remove(w)
+	if w.period > 0 {
+		w.period = d
+	}
+	c.start(w, d)
+	return active
+}
+
+func (w *waiter) stop() bool {
+	c := w.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove(w)
+}
+
+// fakeTimer implements ticker.Timer.
+type fakeTimer struct {
+	*waiter
+}
+
+func (t fakeTimer) Reset(d time.Duration) bool {
+	return t.reset(d)
+}
+
+func (t fakeTimer) Stop() bool {
+	return t.stop()
+}
+
+// fakeTicker implements ticker.Ticker.
+type fakeTicker struct {
+	*waiter
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for Ticker.Reset")
+	}
+	t.reset(d)
+}
+
+func (t fakeTicker) Stop() {
+	t.stop()
+}
@@ -0,0 +1,89 @@
+package clocktest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+	"github.com/goaux/ticker/clocktest"
+)
+
+// TestClock_Timer tests that a timer fires only when the clock is advanced past it
+func TestClock_Timer(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(start)
+	timer := clock.NewTimer(time.Second)
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	select {
+	case at := <-timer.C():
+		if !at.Equal(start.Add(time.Second)) {
+			t.Errorf("expected %v, got %v", start.Add(time.Second), at)
+		}
+	default:
+		t.Fatal("timer did not fire")
+	}
+
+	if timer.Stop() {
+		t.Error("Stop should report false for a fired timer")
+	}
+	if timer.Reset(time.Second) {
+		t.Error("Reset should report false for a fired timer")
+	}
+	if !timer.Stop() {
+		t.Error("Stop should report true for an active timer")
+	}
+}
+
+// TestClock_Ticker tests that a ticker fires every period and drops missed ticks
+func TestClock_Ticker(t *testing.T) {
+	clock := clocktest.NewClock(time.Time{})
+	tk := clock.NewTicker(time.Second)
+	defer tk.Stop()
+
+	clock.Advance(3 * time.Second)
+	<-tk.C()
+	select {
+	case <-tk.C():
+		t.Fatal("expected missed ticks to be dropped")
+	default:
+	}
+
+	if n := clock.Waiters(); n != 1 {
+		t.Errorf("expected 1 waiter, got %d", n)
+	}
+}
+
+// TestClock_Run tests driving ticker.Task.Run with a fake clock
+func TestClock_Run(t *testing.T) {
+	clock := clocktest.NewClock(time.Time{})
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- task.Run(context.Background(), time.Hour, ticker.WithLimit(3), ticker.WithClock(clock))
+	}()
+
+	for i := 0; i < 3; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 executions, got %d", count)
+	}
+}
@@ -0,0 +1,224 @@
+package ticker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RunCron executes the task at the times matching the cron expression expr.
+//
+// expr is a standard 5-field cron expression: minute, hour, day of month, month and
+// day of week, separated by spaces. Each field is *, a value, a range a-b, or a list of
+// them separated by commas, each optionally followed by a step /n. Months and days of
+// week may also be given by their three-letter English names, and both 0 and 7 mean
+// Sunday. As in crontab, if both the day of month and the day of week are restricted,
+// a time matches if either matches. The macros @yearly, @annually, @monthly, @weekly,
+// @daily, @midnight and @hourly are also accepted. For example, "0 9 * * MON-FRI"
+// fires every weekday at 9:00.
+//
+// Times are evaluated in the location of the clock, which is the local time zone by
+// default. RunCron returns an error wrapping ErrInvalidCron for a malformed expression.
+//
+// Options apply as for Run, such as WithLimit, WithOnError or WithImmediate. Options
+// that adjust the interval, such as WithBackoff, WithJitter, WithInterval, WithAlign,
+// WithFirstInterval and WithFixedDelay, have no effect. Like the ticks of Run, a time
+// missed while the task was running fires once as soon as it completes.
+func (task Task) RunCron(ctx context.Context, expr string, options ...Option) error {
+	sched, err := parseCron(expr)
+	if err != nil {
+		return err
+	}
+	s, err := newSession(task, time.Minute, options)
+	if err != nil {
+		return err
+	}
+	s.sched = sched.next
+	return s.run(ctx)
+}
+
+// cronSchedule is a parsed cron expression. Each field is a bit set of the values
+// that match.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar report whether the day fields are unrestricted.
+	domStar, dowStar bool
+}
+
+// cronField describes the range and names of a field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+// cronMacros maps the supported macros to their expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronHorizon bounds the search for the next matching time. It covers the longest gap
+// between leap days, so that a valid expression always matches within it.
+const cronHorizon = 10
+
+// parseCron parses a 5-field cron expression.
+func parseCron(expr string) (*cronSchedule, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidCron, expr, fmt.Sprintf(format, args...))
+	}
+
+	spec := strings.TrimSpace(expr)
+	if m, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, invalid("expected 5 fields, got %d", len(fields))
+	}
+
+	c := &cronSchedule{
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	for i, f := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&c.minute, cronMinute},
+		{&c.hour, cronHour},
+		{&c.dom, cronDom},
+		{&c.month, cronMonth},
+		{&c.dow, cronDow},
+	} {
+		bits, err := f.field.parse(fields[i])
+		if err != nil {
+			return nil, invalid("%s: %v", f.field.name, err)
+		}
+		*f.bits = bits
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1 << 0
+	}
+
+	if ref := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC); c.next(ref).IsZero() {
+		return nil, invalid("never matches")
+	}
+	return c, nil
+}
+
+// parse parses a field of a cron expression and returns the bit set of its values.
+func (f cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		expr, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			expr, step = part[:i], n
+		}
+
+		var lo, hi int
+		switch i := strings.IndexByte(expr, '-'); {
+		case expr == "*":
+			lo, hi = f.min, f.max
+		case i >= 0:
+			var err error
+			if lo, err = f.value(expr[:i]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(expr[i+1:]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", expr)
+			}
+		default:
+			var err error
+			if lo, err = f.value(expr); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if step > 1 {
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of the field, either a number or a name.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// next returns the first time after t that matches the schedule, in the location
+// of t, or the zero time if there is none within cronHorizon years.
+func (c *cronSchedule) next(t time.Time) time.Time {
+	// Each step moves forward in absolute time, even across a daylight saving
+	// time transition.
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronHorizon, 0, 0)
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		switch {
+		case c.month&(1<<m) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !c.day(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// day reports whether the day of t matches the day of month and day of week fields.
+func (c *cronSchedule) day(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<t.Weekday()) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,106 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+	"github.com/goaux/ticker/clocktest"
+)
+
+// TestRunCron tests that RunCron fires at the times matching the expression
+func TestRunCron(t *testing.T) {
+	tests := []struct {
+		expr  string
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			// Sunday afternoon to Monday morning.
+			expr:  "*/15 9-17 * * MON-FRI",
+			start: time.Date(2024, 7, 14, 16, 7, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 15, 9, 15, 0, 0, time.UTC),
+				time.Date(2024, 7, 15, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			// Either the 1st or a Saturday.
+			expr:  "30 0 1 * 6",
+			start: time.Date(2024, 7, 26, 12, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 7, 27, 0, 30, 0, 0, time.UTC),
+				time.Date(2024, 8, 1, 0, 30, 0, 0, time.UTC),
+				time.Date(2024, 8, 3, 0, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			expr:  "@hourly",
+			start: time.Date(2024, 7, 14, 23, 59, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 15, 1, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 15, 2, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			clock := clocktest.NewClock(tt.start)
+			var got []time.Time
+			task := ticker.New(func() error {
+				got = append(got, clock.Now())
+				return nil
+			})
+			done := make(chan error)
+			go func() {
+				done <- task.RunCron(context.Background(), tt.expr,
+					ticker.WithLimit(len(tt.want)),
+					ticker.WithClock(clock),
+				)
+			}()
+			last := tt.want[len(tt.want)-1]
+			for clock.Now().Before(last) {
+				clock.BlockUntil(1)
+				clock.Advance(time.Minute)
+			}
+			if err := <-done; err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("tick %d: expected %v, got %v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+// TestRunCron_Invalid tests that RunCron rejects malformed expressions
+func TestRunCron_Invalid(t *testing.T) {
+	task := ticker.New(func() error { return nil })
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * FOO *",
+		"0 0 30 2 *",
+	} {
+		err := task.RunCron(context.Background(), expr)
+		if !errors.Is(err, ticker.ErrInvalidCron) || !errors.Is(err, ticker.ErrInvalidArgument) {
+			t.Errorf("%q: expected error %v, got %v", expr, ticker.ErrInvalidCron, err)
+		}
+	}
+}
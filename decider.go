@@ -0,0 +1,62 @@
+package ticker
+
+import (
+	"errors"
+	"time"
+)
+
+// Decision describes an execution for the decider set by WithDecider.
+type Decision struct {
+	// Index is the 1-based execution counter, as seen by NewIndexed.
+	Index int
+
+	// Err is the error of the execution, or nil if it succeeded.
+	Err error
+
+	// Took is the duration of the execution.
+	Took time.Duration
+
+	// Elapsed is the time elapsed since the ticker started.
+	Elapsed time.Duration
+
+	// ConsecutiveErrors is the number of failed executions in a row, including this one.
+	ConsecutiveErrors int
+}
+
+// WithDecider returns an Option to decide after each execution whether the ticker
+// continues, with everything known about the execution.
+//
+// fn returns true to continue and false to stop the ticker cleanly, in which case Run
+// returns nil. It replaces the handling of task errors by WithOnError, WithStopOnError,
+// WithMaxErrors and WithMaxConsecutiveErrors: an error does not stop the ticker unless
+// fn says so. WithFailFastFirst and the error returned by WithRecover still stop the
+// ticker, and fn cannot extend WithLimit. The context always wins: once it is done,
+// Run returns the context error whatever fn returns. Until ignores fn.
+func WithDecider(fn func(d Decision) bool) Option {
+	return decider(fn)
+}
+
+type decider func(Decision) bool
+
+func (o decider) apply(c *config) {
+	c.Decider = o
+}
+
+// errDecided stops the ticker when the decider of WithDecider returns false.
+var errDecided = errors.New("ticker: stopped by decider")
+
+// decide consults the decider about an execution and returns the error that stops
+// the ticker, if any.
+func (s *session) decide(n int, err error, took time.Duration) error {
+	d := Decision{
+		Index:             n,
+		Err:               err,
+		Took:              took,
+		Elapsed:           s.c.Clock.Now().Sub(s.start),
+		ConsecutiveErrors: s.consecutive,
+	}
+	if !s.c.Decider(d) {
+		return errDecided
+	}
+	return nil
+}
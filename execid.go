@@ -0,0 +1,45 @@
+package ticker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// WithExecID returns an Option to tag each execution with an ID, for example to
+// correlate the logs of the task with those of the ticker.
+//
+// fn receives the 1-based execution counter and returns the ID of the execution. A nil
+// fn uses the default IDs, which are made of a random prefix drawn for each run and the
+// counter, such as "9f3c2a7b1e4d5c60-42", so that they are unique across runs and
+// processes in practice.
+//
+// The ID is set in the context passed to the task, where ExecIDFromContext reads it. It
+// is also in the context passed to the observers of WithObservers, and in the attribute
+// "exec_id" of the records of WithLogger.
+func WithExecID(fn func(n int) string) Option {
+	return execID(fn)
+}
+
+type execID func(int) string
+
+func (o execID) apply(c *config) {
+	if o == nil {
+		prefix := rand.Uint64()
+		o = func(n int) string {
+			return fmt.Sprintf("%016x-%d", prefix, n)
+		}
+	}
+	c.ExecID = o
+}
+
+// ExecIDFromContext returns the ID of the execution ctx belongs to, as set by
+// WithExecID. It reports false if there is none, such as when the task is not run by a
+// ticker with WithExecID.
+func ExecIDFromContext(ctx context.Context) (string, bool) {
+	info, ok := tickFromContext(ctx)
+	if !ok || !info.hasID {
+		return "", false
+	}
+	return info.id, true
+}
@@ -0,0 +1,62 @@
+package ticker_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// idRecorder is an Observer that records the execution IDs it sees.
+type idRecorder struct {
+	ids *[]string
+}
+
+func (r idRecorder) OnTick(ctx context.Context, n int, took time.Duration, err error) {
+	id, _ := ticker.ExecIDFromContext(ctx)
+	*r.ids = append(*r.ids, id)
+}
+
+func (idRecorder) OnError(context.Context, int, error) {}
+func (idRecorder) OnStop(error)                        {}
+
+// TestWithExecID tests that each execution sees its ID, as do the observers
+func TestWithExecID(t *testing.T) {
+	var got, observed []string
+	task := ticker.NewContext(func(ctx context.Context) error {
+		id, ok := ticker.ExecIDFromContext(ctx)
+		if !ok {
+			t.Errorf("expected an execution ID")
+		}
+		got = append(got, id)
+		return nil
+	})
+	err := task.Run(context.Background(), time.Millisecond,
+		ticker.WithLimit(2),
+		ticker.WithExecID(func(n int) string { return fmt.Sprint("job-", n) }),
+		ticker.WithObservers(idRecorder{&observed}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(got) != "[job-1 job-2]" || fmt.Sprint(observed) != fmt.Sprint(got) {
+		t.Errorf("expected [job-1 job-2], got %v and observed %v", got, observed)
+	}
+
+	got = nil
+	if err := task.Run(context.Background(), time.Millisecond, ticker.WithLimit(2), ticker.WithExecID(nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	re := regexp.MustCompile(`^([0-9a-f]{16})-(\d+)$`)
+	m1, m2 := re.FindStringSubmatch(got[0]), re.FindStringSubmatch(got[1])
+	if m1 == nil || m2 == nil || m1[1] != m2[1] || m1[2] != "1" || m2[2] != "2" {
+		t.Errorf("expected default IDs with a common prefix, got %v", got)
+	}
+
+	if id, ok := ticker.ExecIDFromContext(context.Background()); ok || id != "" {
+		t.Errorf("expected no execution ID outside of a ticker, got %q", id)
+	}
+}
@@ -0,0 +1,4 @@
+package ticker
+
+// InternalConfig is the configuration of a ticker, for the tests of AppliedConfig.
+type InternalConfig = config
@@ -0,0 +1,109 @@
+package ticker
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// NewGroup creates a new Task that executes all the given task functions on each tick.
+//
+// The functions are executed sequentially in the order given, and all of them are
+// executed even if some fail. Their errors are combined with errors.Join and are subject
+// to the same stop or continue semantics as the error of a single task. Each tick runs
+// the whole group to completion before the next tick, so executions of the group never
+// overlap.
+//
+// If no function or a nil function is provided, NewGroup returns nil.
+func NewGroup(tasks ...func() error) Task {
+	if !valid(tasks) {
+		return nil
+	}
+	tasks = append([]func() error(nil), tasks...)
+	return func(context.Context) error {
+		var errs []error
+		for _, task := range tasks {
+			if err := task(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// NewParallel creates a new Task that executes all the given task functions concurrently
+// on each tick.
+//
+// Each execution waits for all the functions to finish, so executions of the group never
+// overlap. The number of functions running at the same time can be limited with
+// WithConcurrency. Their errors are combined with errors.Join, in the order the functions
+// were given, and are subject to the same stop or continue semantics as the error of a
+// single task.
+//
+// A panic in a function is recovered on its goroutine and, once all the functions have
+// finished, raised again on the goroutine of the execution, so that WithRecover,
+// WithPanicAsError and the Recover middleware apply to it as to a single task. If
+// several functions panic, the panic of the first one in the order given is raised.
+//
+// If no function or a nil function is provided, NewParallel returns nil.
+func NewParallel(tasks ...func() error) Task {
+	if !valid(tasks) {
+		return nil
+	}
+	tasks = append([]func() error(nil), tasks...)
+	return func(ctx context.Context) error {
+		var sem chan struct{}
+		if info, _ := tickFromContext(ctx); info.concurrency > 0 {
+			sem = make(chan struct{}, info.concurrency)
+		}
+		errs := make([]error, len(tasks))
+		panics := make([]any, len(tasks))
+		var wg sync.WaitGroup
+		for i, task := range tasks {
+			if sem != nil {
+				sem <- struct{}{}
+			}
+			wg.Add(1)
+			go func(i int, task func() error) {
+				defer wg.Done()
+				if sem != nil {
+					defer func() { <-sem }()
+				}
+				defer func() { panics[i] = recover() }()
+				errs[i] = task()
+			}(i, task)
+		}
+		wg.Wait()
+		for _, v := range panics {
+			if v != nil {
+				panic(v)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// WithConcurrency returns an Option to limit how many functions of a Task created by
+// NewParallel run at the same time. A non-positive value means no limit.
+func WithConcurrency(n int) Option {
+	return concurrency(n)
+}
+
+type concurrency int
+
+func (o concurrency) apply(c *config) {
+	c.Concurrency = int(o)
+}
+
+// valid reports whether tasks is not empty and contains no nil function.
+func valid(tasks []func() error) bool {
+	if len(tasks) == 0 {
+		return false
+	}
+	for _, task := range tasks {
+		if task == nil {
+			return false
+		}
+	}
+	return true
+}
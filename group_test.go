@@ -0,0 +1,126 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestNewGroup tests that NewGroup runs all tasks in order and joins their errors
+func TestNewGroup(t *testing.T) {
+	if ticker.NewGroup() != nil {
+		t.Error("NewGroup() should return a nil Task")
+	}
+	if ticker.NewGroup(func() error { return nil }, nil) != nil {
+		t.Error("NewGroup with a nil function should return a nil Task")
+	}
+
+	Err1 := errors.New("error 1")
+	Err3 := errors.New("error 3")
+	var calls []int
+	task := ticker.NewGroup(
+		func() error { calls = append(calls, 1); return Err1 },
+		func() error { calls = append(calls, 2); return nil },
+		func() error { calls = append(calls, 3); return Err3 },
+	)
+
+	stats, err := task.RunStats(context.Background(), 10*time.Millisecond, ticker.WithLimit(2), ticker.WithStopOnError(false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(calls) != "[1 2 3 1 2 3]" {
+		t.Errorf("expected tasks to run in order on each tick, got %v", calls)
+	}
+	if stats.Errors != 2 {
+		t.Errorf("expected 2 failed executions, got %d", stats.Errors)
+	}
+
+	err = task.Run(context.Background(), 10*time.Millisecond, ticker.WithImmediate(true))
+	if !errors.Is(err, Err1) || !errors.Is(err, Err3) {
+		t.Errorf("expected joined errors, got %v", err)
+	}
+}
+
+// TestNewParallel tests that NewParallel runs tasks concurrently within the concurrency limit
+func TestNewParallel(t *testing.T) {
+	if ticker.NewParallel() != nil {
+		t.Error("NewParallel() should return a nil Task")
+	}
+	if ticker.NewParallel(nil) != nil {
+		t.Error("NewParallel with a nil function should return a nil Task")
+	}
+
+	var active, peak atomic.Int32
+	probe := func(err error) func() error {
+		return func() error {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			active.Add(-1)
+			return err
+		}
+	}
+	Err1 := errors.New("error 1")
+	Err4 := errors.New("error 4")
+	task := ticker.NewParallel(probe(Err1), probe(nil), probe(nil), probe(Err4))
+
+	for _, tt := range []struct {
+		concurrency int
+		peak        int32
+	}{
+		{0, 4},
+		{2, 2},
+	} {
+		peak.Store(0)
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithImmediate(true), ticker.WithConcurrency(tt.concurrency))
+		if !errors.Is(err, Err1) || !errors.Is(err, Err4) {
+			t.Errorf("concurrency=%d: expected joined errors, got %v", tt.concurrency, err)
+		}
+		if p := peak.Load(); p != tt.peak {
+			t.Errorf("concurrency=%d: expected %d tasks at the same time, got %d", tt.concurrency, tt.peak, p)
+		}
+	}
+}
+
+// TestNewParallel_Panic tests that a panic in a function of NewParallel is handled like a
+// panic in the task
+func TestNewParallel_Panic(t *testing.T) {
+	var ran atomic.Int32
+	task := ticker.NewParallel(
+		func() error { ran.Add(1); return nil },
+		func() error { ran.Add(1); panic("boom") },
+		func() error { ran.Add(1); return nil },
+	)
+	for _, tt := range []struct {
+		name    string
+		options []ticker.Option
+	}{
+		{"recover", []ticker.Option{ticker.WithRecover(func(v any) error { return fmt.Errorf("%v", v) })}},
+		{"panic as error", []ticker.Option{ticker.WithPanicAsError(true)}},
+		{"middleware", []ticker.Option{ticker.WithMiddleware(ticker.Recover())}},
+		{"concurrency", []ticker.Option{ticker.WithPanicAsError(true), ticker.WithConcurrency(1)}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ran.Store(0)
+			options := append([]ticker.Option{ticker.WithImmediate(true), ticker.WithLimit(1)}, tt.options...)
+			err := task.Run(context.Background(), time.Hour, options...)
+			if err == nil || !strings.Contains(err.Error(), "boom") {
+				t.Errorf("expected the panic as an error, got %v", err)
+			}
+			if n := ran.Load(); n != 3 {
+				t.Errorf("expected all 3 functions to run, got %d", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package ticker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Handle controls a ticker started by Start.
+//
+// All methods of Handle are safe for concurrent use.
+type Handle struct {
+	// mu guards run, which Restart replaces.
+	mu  sync.Mutex
+	run *handleRun
+
+	// ctx and newSession start the runs of the ticker.
+	ctx        context.Context
+	newSession func() (*session, error)
+}
+
+// handleRun is a run of the ticker of a Handle.
+type handleRun struct {
+	s    *session
+	done chan struct{}
+	err  error
+	once sync.Once
+}
+
+// Start runs the task like Run, but in a new goroutine, and returns immediately.
+//
+// The returned Handle can be used to pause, resume and stop the ticker, and to wait
+// for it to finish. If the arguments are invalid, the ticker does not start and Wait
+// returns the same error Run would return.
+func (task Task) Start(ctx context.Context, d time.Duration, options ...Option) *Handle {
+	h := &Handle{
+		ctx: ctx,
+		newSession: func() (*session, error) {
+			return newSession(task, d, options)
+		},
+	}
+	h.run = h.launch(h.newSession())
+	return h
+}
+
+// launch starts a run of the ticker with s, or records err if the arguments are invalid.
+func (h *Handle) launch(s *session, err error) *handleRun {
+	r := &handleRun{done: make(chan struct{})}
+	if err != nil {
+		r.err = err
+		close(r.done)
+		return r
+	}
+	s.stop = make(chan struct{})
+	s.resetc = make(chan struct{}, 1)
+	s.limitc = make(chan struct{}, 1)
+	r.s = s
+	go func() {
+		defer close(r.done)
+		r.err = s.run(h.ctx)
+	}()
+	return r
+}
+
+// current returns the current run of the ticker.
+func (h *Handle) current() *handleRun {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.run
+}
+
+// Restart starts the ticker again after it has finished, with the same task, interval
+// and options as Start.
+//
+// With preserveStats, the new run continues the previous one: the Stats accumulate, and
+// the limit of WithLimit and the budget of WithTotalExecBudget continue from where they
+// were left, so that a ticker that reached its limit finishes right away. Otherwise,
+// everything starts over as with Start. An interval changed by Reset is not kept, and the
+// context given to Start is used again, so a ticker whose context is done finishes right
+// away.
+//
+// Restart returns ErrRunning if the ticker is still running, and the error of Start if
+// the arguments were invalid. A ticker started by StartNamed is not registered again.
+func (h *Handle) Restart(preserveStats bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	prev := h.run
+	if prev.s == nil {
+		return prev.err
+	}
+	select {
+	case <-prev.done:
+	default:
+		return ErrRunning
+	}
+	s, err := h.newSession()
+	if err != nil {
+		return err
+	}
+	if preserveStats {
+		prev.s.mu.Lock()
+		s.stats, s.limit, s.spent = prev.s.stats, prev.s.limit, prev.s.spent
+		prev.s.mu.Unlock()
+	}
+	h.run = h.launch(s, nil)
+	return nil
+}
+
+// Pause suppresses executions of the task until Resume is called.
+//
+// The ticker keeps its schedule while paused; ticks that fire while paused are skipped
+// and do not count toward WithLimit.
+func (h *Handle) Pause() {
+	r := h.current()
+	if r.s != nil {
+		r.s.paused.Store(true)
+	}
+}
+
+// Resume resumes executions of the task suppressed by Pause.
+// The task is executed again on the next scheduled tick.
+func (h *Handle) Resume() {
+	r := h.current()
+	if r.s != nil {
+		r.s.paused.Store(false)
+	}
+}
+
+// Stop stops the ticker cleanly. It does not wait for the ticker to finish; use Wait for that.
+//
+// An execution in progress is not interrupted. Stopping an already stopped ticker has no effect.
+func (h *Handle) Stop() {
+	r := h.current()
+	if r.s != nil {
+		r.once.Do(func() { close(r.s.stop) })
+	}
+}
+
+// NextTick returns the time at which the next tick is scheduled, for example to show
+// "next run in 12s".
+//
+// With WithFixedDelay, it is the time computed after the last execution completed.
+// While an execution is in progress, it is the time of the tick that started it.
+// NextTick returns the zero time if the ticker is paused, stopped or finished.
+func (h *Handle) NextTick() time.Time {
+	r := h.current()
+	if r.s == nil || r.s.paused.Load() {
+		return time.Time{}
+	}
+	select {
+	case <-r.s.stop:
+		return time.Time{}
+	default:
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.next
+}
+
+// Stats returns a snapshot of the Stats of the ticker, which may still be running.
+//
+// The snapshot is taken at a point in time and may be stale as soon as it is returned.
+// Once the ticker has finished, it is final. If the arguments were invalid, Stats
+// returns the zero Stats.
+func (h *Handle) Stats() Stats {
+	r := h.current()
+	if r.s == nil {
+		return Stats{}
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.stats
+}
+
+// Healthy reports whether the ticker is healthy as tracked by WithHealth: false after
+// the threshold of consecutive failed executions, and true again after a successful one.
+//
+// It reports true without WithHealth, and false if the arguments were invalid. Once the
+// ticker has finished, the state is final until Restart.
+func (h *Handle) Healthy() bool {
+	r := h.current()
+	return r.s != nil && !r.s.unhealthy.Load()
+}
+
+// LastError returns the error of the last execution, or nil if it succeeded.
+//
+// Together with LastRun, it gives a lightweight status without the full Stats. Both are
+// updated together after each execution, and reflect the new run after Restart.
+// LastError returns nil before the first execution, and the error of Start if the
+// arguments were invalid.
+func (h *Handle) LastError() error {
+	r := h.current()
+	if r.s == nil {
+		return r.err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.last
+}
+
+// LastRun returns the time the last execution completed, or the zero time if there has
+// been none or the arguments were invalid.
+func (h *Handle) LastRun() time.Time {
+	r := h.current()
+	if r.s == nil {
+		return time.Time{}
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.lastRun
+}
+
+// Wait waits for the ticker to finish and returns its final error.
+//
+// Wait returns nil if the ticker was stopped by Stop or reached its execution limit.
+func (h *Handle) Wait() error {
+	r := h.current()
+	<-r.done
+	return r.err
+}
+
+// Drain waits for the ticker to finish and then for its executions in flight with
+// WithAsync to complete, or for ctx to be done, whichever comes first.
+//
+// Drain does not stop the ticker: call Stop, or cancel its context, first. It returns
+// nil once everything has completed, or the error of ctx if ctx is done first, in which
+// case the executions keep running. With WithShutdownTimeout, Drain waits for the
+// executions at most the timeout once the ticker has finished, and then returns
+// ErrShutdownTimeout. Without WithAsync, Drain is like Wait bounded by ctx, but does
+// not return the error of the ticker.
+func (h *Handle) Drain(ctx context.Context) error {
+	r := h.current()
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if r.s == nil {
+		return nil
+	}
+	drained := make(chan struct{})
+	go func() {
+		r.s.wg.Wait()
+		close(drained)
+	}()
+	var timeout <-chan time.Time
+	if d := r.s.c.Shutdown; d > 0 {
+		t := r.s.c.Clock.NewTimer(d)
+		defer t.Stop()
+		timeout = t.C()
+	}
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		return joinStop(ErrShutdownTimeout, r.s.contextErr(h.ctx))
+	}
+}
+
+// Reset changes the interval of the running ticker to d.
+//
+// The pending tick is rescheduled right away to d after the previous tick, or to now if
+// that time has already passed; the following ticks fire every d. Reset also resets any
+// backoff to d. A d below the bound of WithMinInterval is raised to it, as any interval.
+// Reset returns ErrNonPositiveInterval if d is not positive, and has no effect on a
+// ticker that has finished.
+func (h *Handle) Reset(d time.Duration) error {
+	r := h.current()
+	if d <= 0 {
+		return ErrNonPositiveInterval
+	}
+	if r.s == nil {
+		return nil
+	}
+	r.s.mu.Lock()
+	r.s.d, r.s.iv = d, d
+	r.s.mu.Unlock()
+	select {
+	case r.s.resetc <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// SetLimit sets the number of executions left to n, for example to grant 10 more runs.
+//
+// A negative n removes the limit, and 0 stops the ticker before any further execution.
+// An execution counts toward the limit when it starts, so an execution in progress is
+// not affected: it completes, and n applies to the executions that start after SetLimit
+// returns. SetLimit has no effect on a ticker that has finished.
+func (h *Handle) SetLimit(n int) {
+	r := h.current()
+	if r.s == nil {
+		return
+	}
+	r.s.mu.Lock()
+	r.s.limit = n
+	r.s.mu.Unlock()
+	select {
+	case r.s.limitc <- struct{}{}:
+	default:
+	}
+}
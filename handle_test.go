@@ -0,0 +1,420 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+	"github.com/goaux/ticker/clocktest"
+)
+
+// TestStart tests that Start runs the task until Stop is called
+func TestStart(t *testing.T) {
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+
+	h := task.Start(context.Background(), 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	h.Stop()
+	h.Stop()
+
+	if err := h.Wait(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count.Load() == 0 {
+		t.Error("expected at least one execution before Stop")
+	}
+}
+
+// TestStart_InvalidArgument tests that Wait reports invalid arguments
+func TestStart_InvalidArgument(t *testing.T) {
+	h := ticker.Task(nil).Start(context.Background(), time.Second)
+	if err := h.Wait(); !errors.Is(err, ticker.ErrNilFunction) {
+		t.Errorf("expected error %v, got %v", ticker.ErrNilFunction, err)
+	}
+}
+
+// TestHandle_Pause tests that Pause suppresses executions until Resume
+func TestHandle_Pause(t *testing.T) {
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+
+	h := task.Start(context.Background(), 10*time.Millisecond, ticker.WithLimit(3))
+	h.Pause()
+	time.Sleep(50 * time.Millisecond)
+	if n := count.Load(); n != 0 {
+		t.Errorf("expected no executions while paused, got %d", n)
+	}
+
+	h.Resume()
+	if err := h.Wait(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n := count.Load(); n != 3 {
+		t.Errorf("expected 3 executions, got %d", n)
+	}
+}
+
+// TestHandle_Wait tests that Wait returns the error of the task
+func TestHandle_Wait(t *testing.T) {
+	ErrTask := errors.New("task error")
+	task := ticker.New(func() error { return ErrTask })
+
+	h := task.Start(context.Background(), 10*time.Millisecond)
+	if err := h.Wait(); !errors.Is(err, ErrTask) {
+		t.Errorf("expected error %v, got %v", ErrTask, err)
+	}
+}
+
+// TestHandle_Reset tests that Reset changes the interval of a running ticker
+func TestHandle_Reset(t *testing.T) {
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+
+	h := task.Start(context.Background(), time.Hour, ticker.WithLimit(2))
+	if err := h.Reset(0); !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected error %v, got %v", ticker.ErrNonPositiveInterval, err)
+	}
+	if err := h.Reset(10 * time.Millisecond); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- h.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		h.Stop()
+		t.Fatal("expected the new interval to take effect")
+	}
+	if n := count.Load(); n != 2 {
+		t.Errorf("expected 2 executions, got %d", n)
+	}
+}
+
+// TestHandle_ResetMinInterval tests that Reset does not reschedule the pending tick
+// below the bound of WithMinInterval
+func TestHandle_ResetMinInterval(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(start)
+	task := ticker.New(func() error { return nil })
+	h := task.Start(context.Background(), time.Hour, ticker.WithClock(clock), ticker.WithMinInterval(time.Minute))
+	defer h.Stop()
+
+	clock.BlockUntil(1)
+	if err := h.Reset(time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The loop reschedules the pending tick shortly after Reset returns.
+	for i := 0; h.NextTick().Equal(start.Add(time.Hour)); i++ {
+		if i == 100 {
+			t.Fatal("expected the pending tick to be rescheduled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got, want := h.NextTick(), start.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestHandle_NextTick tests that NextTick reports the pending tick
+func TestHandle_NextTick(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(start)
+	executed := make(chan struct{})
+	task := ticker.New(func() error {
+		executed <- struct{}{}
+		return nil
+	})
+	h := task.Start(context.Background(), time.Minute, ticker.WithClock(clock))
+
+	clock.BlockUntil(1)
+	if got, want := h.NextTick(), start.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	h.Pause()
+	if got := h.NextTick(); !got.IsZero() {
+		t.Errorf("expected the zero time while paused, got %v", got)
+	}
+	h.Resume()
+
+	clock.Advance(time.Minute)
+	<-executed
+	clock.BlockUntil(1)
+	if got, want := h.NextTick(), start.Add(2*time.Minute); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	h.Stop()
+	if got := h.NextTick(); !got.IsZero() {
+		t.Errorf("expected the zero time once stopped, got %v", got)
+	}
+	if err := h.Wait(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestHandle_Stats tests that Stats reports the activity while the ticker is running
+func TestHandle_Stats(t *testing.T) {
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	task := ticker.NewIndexed(func(n int) error {
+		if n%2 == 0 {
+			return errors.New("even")
+		}
+		return nil
+	})
+	h := task.Start(context.Background(), time.Minute,
+		ticker.WithClock(clock),
+		ticker.WithStopOnError(false),
+	)
+	for i := 0; i < 3; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}
+	clock.BlockUntil(1)
+	if got, want := h.Stats(), (ticker.Stats{Executions: 3, Errors: 1}); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	h.Stop()
+	if err := h.Wait(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	h = ticker.Task(nil).Start(context.Background(), time.Minute)
+	if got := h.Stats(); got != (ticker.Stats{}) {
+		t.Errorf("expected zero Stats, got %+v", got)
+	}
+}
+
+// TestHandle_SetLimit tests that SetLimit changes the number of executions left
+func TestHandle_SetLimit(t *testing.T) {
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+	h := task.Start(context.Background(), time.Minute, ticker.WithClock(clock), ticker.WithLimit(1))
+	h.SetLimit(2)
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := count.Load(); n != 2 {
+		t.Errorf("expected 2 executions, got %d", n)
+	}
+
+	count.Store(0)
+	h = task.Start(context.Background(), time.Minute, ticker.WithClock(clock))
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	clock.BlockUntil(1)
+	h.SetLimit(0)
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := count.Load(); n != 1 {
+		t.Errorf("expected 1 execution, got %d", n)
+	}
+}
+
+// TestHandle_Drain tests that Drain waits for the executions in flight with WithAsync
+func TestHandle_Drain(t *testing.T) {
+	var started, completed atomic.Int32
+	release := make(chan struct{})
+	task := ticker.New(func() error {
+		started.Add(1)
+		<-release
+		completed.Add(1)
+		return nil
+	})
+	h := task.Start(context.Background(), time.Millisecond, ticker.WithAsync(true), ticker.WithLimit(3))
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := h.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded while the executions are blocked, got %v", err)
+	}
+
+	close(release)
+	if err := h.Drain(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, m := started.Load(), completed.Load(); n != 3 || m != 3 {
+		t.Errorf("expected 3 executions started and completed, got %d and %d", n, m)
+	}
+}
+
+// TestHandle_Drain_ShutdownTimeout tests that Drain abandons the executions after the timeout of WithShutdownTimeout
+func TestHandle_Drain_ShutdownTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	task := ticker.New(func() error {
+		<-release
+		return nil
+	})
+	h := task.Start(context.Background(), time.Millisecond,
+		ticker.WithAsync(true),
+		ticker.WithLimit(1),
+		ticker.WithShutdownTimeout(10*time.Millisecond),
+	)
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.Drain(context.Background()); !errors.Is(err, ticker.ErrShutdownTimeout) {
+		t.Errorf("expected ErrShutdownTimeout, got %v", err)
+	}
+}
+
+// TestHandle_Restart tests that Restart preserves or resets the progress of the ticker
+func TestHandle_Restart(t *testing.T) {
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+	// tick fires n ticks of the running ticker.
+	tick := func(n int) {
+		for i := 0; i < n; i++ {
+			clock.BlockUntil(1)
+			clock.Advance(time.Minute)
+		}
+	}
+
+	h := task.Start(context.Background(), time.Minute, ticker.WithClock(clock), ticker.WithLimit(5))
+	tick(2)
+	clock.BlockUntil(1)
+	if err := h.Restart(true); !errors.Is(err, ticker.ErrRunning) {
+		t.Errorf("expected ErrRunning while running, got %v", err)
+	}
+	h.Stop()
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := h.Restart(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tick(3)
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := h.Stats().Executions; n != 5 {
+		t.Errorf("expected the limit and stats to continue to 5 executions, got %d", n)
+	}
+
+	if err := h.Restart(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tick(5)
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := h.Stats().Executions; n != 5 {
+		t.Errorf("expected 5 executions after a reset, got %d", n)
+	}
+	if n := count.Load(); n != 10 {
+		t.Errorf("expected 10 executions in total, got %d", n)
+	}
+
+	h = task.Start(context.Background(), 0)
+	if err := h.Restart(false); !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected ErrNonPositiveInterval, got %v", err)
+	}
+}
+
+// TestHandle_Healthy tests that Healthy flips with the outcomes of the executions
+func TestHandle_Healthy(t *testing.T) {
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	outcomes := []bool{false, false, true, false, false, false, true}
+	want := []bool{true, true, true, true, true, false, true}
+	task := ticker.NewIndexed(func(n int) error {
+		if !outcomes[n-1] {
+			return errors.New("task error")
+		}
+		return nil
+	})
+	h := task.Start(context.Background(), time.Minute,
+		ticker.WithClock(clock),
+		ticker.WithStopOnError(false),
+		ticker.WithHealth(3),
+	)
+	defer h.Stop()
+	if !h.Healthy() {
+		t.Errorf("expected healthy before any execution")
+	}
+	for i := range outcomes {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		clock.BlockUntil(1)
+		if got := h.Healthy(); got != want[i] {
+			t.Errorf("execution %d: expected healthy %v, got %v", i+1, want[i], got)
+		}
+	}
+
+	if h := task.Start(context.Background(), 0); h.Healthy() {
+		t.Errorf("expected unhealthy for invalid arguments")
+	}
+}
+
+// TestHandle_LastRun tests that LastError and LastRun report the last execution
+func TestHandle_LastRun(t *testing.T) {
+	ErrTask := errors.New("task error")
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	task := ticker.NewIndexed(func(n int) error {
+		if n%2 == 0 {
+			return ErrTask
+		}
+		return nil
+	})
+	h := task.Start(context.Background(), time.Minute,
+		ticker.WithClock(clock),
+		ticker.WithStopOnError(false),
+	)
+	defer h.Stop()
+	if err, at := h.LastError(), h.LastRun(); err != nil || !at.IsZero() {
+		t.Errorf("expected nil and the zero time before any execution, got %v and %v", err, at)
+	}
+	for n := 1; n <= 3; n++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		clock.BlockUntil(1)
+		var want error
+		if n%2 == 0 {
+			want = ErrTask
+		}
+		if err := h.LastError(); err != want {
+			t.Errorf("execution %d: expected error %v, got %v", n, want, err)
+		}
+		if at := h.LastRun(); !at.Equal(clock.Now()) {
+			t.Errorf("execution %d: expected last run at %v, got %v", n, clock.Now(), at)
+		}
+	}
+
+	if h := task.Start(context.Background(), 0); !errors.Is(h.LastError(), ticker.ErrNonPositiveInterval) || !h.LastRun().IsZero() {
+		t.Errorf("expected ErrNonPositiveInterval and the zero time for invalid arguments")
+	}
+}
@@ -0,0 +1,45 @@
+package ticker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithLogger returns an Option to log the executions of the task to logger.
+//
+// Each execution is logged at debug level with the message "tick executed" and the
+// attributes "count" (the 1-based execution counter) and "duration". A failed execution
+// is additionally logged at error level with the message "tick failed" and the attribute
+// "error". If the ticker is named by WithName, every record also has the attribute
+// "ticker" with the name, and with WithExecID, the attribute "exec_id" with the ID of
+// the execution. A nil logger, the default, logs nothing.
+func WithLogger(logger *slog.Logger) Option {
+	return loggerOption{logger}
+}
+
+type loggerOption struct {
+	logger *slog.Logger
+}
+
+func (o loggerOption) apply(c *config) {
+	c.Logger = o.logger
+}
+
+// log logs the execution n that took took and returned err.
+func (s *session) log(ctx context.Context, n int, took time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.Int("count", n),
+		slog.Duration("duration", took),
+	}
+	if s.c.Name != "" {
+		attrs = append(attrs, slog.String("ticker", s.c.Name))
+	}
+	if id, ok := ExecIDFromContext(ctx); ok {
+		attrs = append(attrs, slog.String("exec_id", id))
+	}
+	s.c.Logger.LogAttrs(ctx, slog.LevelDebug, "tick executed", attrs...)
+	if err != nil {
+		s.c.Logger.LogAttrs(ctx, slog.LevelError, "tick failed", append(attrs, slog.Any("error", err))...)
+	}
+}
@@ -0,0 +1,114 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// recordHandler is a slog.Handler that captures log records.
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordHandler) WithGroup(string) slog.Handler { return h }
+
+// attrs returns the attributes of r by key.
+func attrs(r slog.Record) map[string]slog.Value {
+	m := map[string]slog.Value{}
+	r.Attrs(func(a slog.Attr) bool {
+		m[a.Key] = a.Value
+		return true
+	})
+	return m
+}
+
+// TestWithLogger tests the WithLogger option
+func TestWithLogger(t *testing.T) {
+	ErrTask := errors.New("task error")
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		if count == 2 {
+			return ErrTask
+		}
+		return nil
+	})
+
+	h := &recordHandler{}
+	err := task.Run(context.Background(), 10*time.Millisecond,
+		ticker.WithLimit(2),
+		ticker.WithStopOnError(false),
+		ticker.WithLogger(slog.New(h)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		level slog.Level
+		msg   string
+		count int64
+	}{
+		{slog.LevelDebug, "tick executed", 1},
+		{slog.LevelDebug, "tick executed", 2},
+		{slog.LevelError, "tick failed", 2},
+	}
+	if len(h.records) != len(want) {
+		t.Fatalf("expected %d records, got %d", len(want), len(h.records))
+	}
+	for i, w := range want {
+		r := h.records[i]
+		a := attrs(r)
+		if r.Level != w.level || r.Message != w.msg || a["count"].Int64() != w.count {
+			t.Errorf("record %d: expected %v %q count=%d, got %v %q count=%v", i, w.level, w.msg, w.count, r.Level, r.Message, a["count"])
+		}
+		if _, ok := a["duration"]; !ok {
+			t.Errorf("record %d: expected a duration attribute", i)
+		}
+	}
+	if e, ok := attrs(h.records[2])["error"].Any().(error); !ok || !errors.Is(e, ErrTask) {
+		t.Errorf("expected an error attribute %v", ErrTask)
+	}
+}
+
+// TestWithName tests that WithName tags log records and errors
+func TestWithName(t *testing.T) {
+	ErrTask := errors.New("task error")
+	task := ticker.New(func() error { return ErrTask })
+
+	h := &recordHandler{}
+	err := task.Run(context.Background(), 10*time.Millisecond,
+		ticker.WithImmediate(true),
+		ticker.WithName("poller"),
+		ticker.WithLogger(slog.New(h)),
+	)
+	if !errors.Is(err, ErrTask) {
+		t.Errorf("expected error %v, got %v", ErrTask, err)
+	}
+	if want := `ticker "poller": task error`; err == nil || err.Error() != want {
+		t.Errorf("expected error message %q, got %v", want, err)
+	}
+	for i, r := range h.records {
+		if name := attrs(r)["ticker"].String(); name != "poller" {
+			t.Errorf("record %d: expected ticker=poller, got %q", i, name)
+		}
+	}
+}
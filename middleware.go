@@ -0,0 +1,91 @@
+package ticker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Middleware wraps a Task with a reusable behavior, such as timing, logging or
+// recovery, like an HTTP middleware wraps a handler.
+type Middleware func(Task) Task
+
+// WithMiddleware returns an Option to wrap the task with middlewares once, before the
+// ticker starts.
+//
+// The first middleware listed is the outermost: WithMiddleware(a, b) executes a(b(task)),
+// so a sees what b returns. WithMiddleware is additive, and the middlewares of an
+// earlier WithMiddleware wrap those of a later one. The wrapped task is what the other
+// options see, so WithRetry retries it all, and WithTimeout bounds it all. Nil
+// middlewares are ignored; a middleware that returns a nil Task makes Run return
+// ErrNilFunction.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return middlewareSet(middlewares)
+}
+
+type middlewareSet []Middleware
+
+func (o middlewareSet) apply(c *config) {
+	for _, m := range o {
+		if m != nil {
+			c.Middleware = append(c.Middleware, m)
+		}
+	}
+}
+
+// wrap wraps task with the middlewares of WithMiddleware, the first being the outermost.
+func (c *config) wrap(task Task) Task {
+	for i := len(c.Middleware) - 1; i >= 0 && task != nil; i-- {
+		task = c.Middleware[i](task)
+	}
+	return task
+}
+
+// Recover returns a Middleware that turns a panic in the task into a *PanicError,
+// which wraps ErrPanic and captures the stack, like WithPanicAsError.
+func Recover() Middleware {
+	return func(next Task) Task {
+		return func(ctx context.Context) (err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					err = newPanicError(v)
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
+
+// LogErrors returns a Middleware that logs the errors of the task to logger at error
+// level, with the message "task failed" and the attributes "count" (the 1-based
+// execution counter) and "error". The errors are returned unchanged. A nil logger
+// means slog.Default.
+func LogErrors(logger *slog.Logger) Middleware {
+	return func(next Task) Task {
+		return func(ctx context.Context) error {
+			err := next(ctx)
+			if err != nil {
+				l := logger
+				if l == nil {
+					l = slog.Default()
+				}
+				info, _ := tickFromContext(ctx)
+				l.LogAttrs(ctx, slog.LevelError, "task failed", slog.Int("count", info.n), slog.Any("error", err))
+			}
+			return err
+		}
+	}
+}
+
+// Timed returns a Middleware that calls fn after each execution of the task with the
+// time it took, as measured by the real clock, and its error.
+func Timed(fn func(took time.Duration, err error)) Middleware {
+	return func(next Task) Task {
+		return func(ctx context.Context) error {
+			start := time.Now()
+			err := next(ctx)
+			fn(time.Since(start), err)
+			return err
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package ticker_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestWithMiddleware tests that the middlewares wrap the task, the first listed being the outermost
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) ticker.Middleware {
+		return func(next ticker.Task) ticker.Task {
+			return func(ctx context.Context) error {
+				calls = append(calls, name+">")
+				err := next(ctx)
+				calls = append(calls, "<"+name)
+				return err
+			}
+		}
+	}
+	task := ticker.New(func() error {
+		calls = append(calls, "task")
+		return nil
+	})
+	err := task.RunN(context.Background(), time.Millisecond, 1,
+		ticker.WithMiddleware(trace("a"), nil, trace("b")),
+		ticker.WithMiddleware(trace("c")),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(calls); got != "[a> b> c> task <c <b <a]" {
+		t.Errorf("expected [a> b> c> task <c <b <a], got %s", got)
+	}
+
+	none := func(ticker.Task) ticker.Task { return nil }
+	if err := task.Run(context.Background(), time.Millisecond, ticker.WithMiddleware(none)); !errors.Is(err, ticker.ErrNilFunction) {
+		t.Errorf("expected ErrNilFunction, got %v", err)
+	}
+}
+
+// TestMiddlewares tests the built-in middlewares
+func TestMiddlewares(t *testing.T) {
+	ErrTask := errors.New("task error")
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	var timed []error
+	task := ticker.NewIndexed(func(n int) error {
+		if n == 1 {
+			return ErrTask
+		}
+		panic("boom")
+	})
+	err := task.Run(context.Background(), time.Millisecond,
+		ticker.WithImmediate(true),
+		ticker.WithLimit(2),
+		ticker.WithStopOnError(false),
+		ticker.WithMiddleware(
+			ticker.Timed(func(took time.Duration, err error) { timed = append(timed, err) }),
+			ticker.LogErrors(logger),
+			ticker.Recover(),
+		),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(timed) != 2 || timed[0] != ErrTask || !errors.Is(timed[1], ticker.ErrPanic) {
+		t.Errorf("expected the task error and then a panic, got %v", timed)
+	}
+	out := buf.String()
+	if strings.Count(out, `msg="task failed"`) != 2 || !strings.Contains(out, "count=1") || !strings.Contains(out, "count=2") {
+		t.Errorf("expected both errors logged, got %s", out)
+	}
+}
@@ -0,0 +1,55 @@
+package ticker
+
+import (
+	"context"
+	"time"
+)
+
+// Observer receives the events of a ticker, for example to log them, record metrics
+// or trace them. It is set by WithObservers.
+type Observer interface {
+	// OnTick is called after each execution of the task, with the context of the
+	// execution, its 1-based execution counter, the time it took and its error, if any.
+	// ExecIDFromContext reads the ID of the execution from ctx.
+	OnTick(ctx context.Context, n int, took time.Duration, err error)
+
+	// OnError is called after OnTick for each execution that failed.
+	OnError(ctx context.Context, n int, err error)
+
+	// OnStop is called once when the ticker stops, with the error Run returns.
+	OnStop(err error)
+}
+
+// WithObservers returns an Option to add observers that receive the events of the
+// ticker.
+//
+// Unlike the options that hold a single callback, WithObservers is additive: the
+// observers of every WithObservers are kept, so that a standard set can be bundled into
+// an option and combined with others. Each event is delivered to all the observers in
+// the order they were added. A panic in an observer is recovered and discarded, so that
+// it neither stops the ticker nor keeps the following observers from receiving the
+// event. With WithAsync, the events of different executions may be delivered
+// concurrently. Nil observers are ignored.
+func WithObservers(observers ...Observer) Option {
+	return observerSet(observers)
+}
+
+type observerSet []Observer
+
+func (o observerSet) apply(c *config) {
+	for _, obs := range o {
+		if obs != nil {
+			c.Observers = append(c.Observers, obs)
+		}
+	}
+}
+
+// notify delivers an event to each observer of WithObservers, isolating their panics.
+func (s *session) notify(event func(Observer)) {
+	for _, obs := range s.c.Observers {
+		func() {
+			defer func() { _ = recover() }()
+			event(obs)
+		}()
+	}
+}
@@ -0,0 +1,60 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// recorder is an Observer that records the events it receives.
+type recorder struct {
+	name   string
+	events *[]string
+}
+
+func (r recorder) OnTick(_ context.Context, n int, took time.Duration, err error) {
+	*r.events = append(*r.events, fmt.Sprintf("%s:tick%d", r.name, n))
+}
+
+func (r recorder) OnError(_ context.Context, n int, err error) {
+	*r.events = append(*r.events, fmt.Sprintf("%s:error%d", r.name, n))
+}
+
+func (r recorder) OnStop(err error) {
+	*r.events = append(*r.events, fmt.Sprintf("%s:stop(%v)", r.name, err))
+}
+
+// panicker is an Observer that panics on every event.
+type panicker struct{}
+
+func (panicker) OnTick(context.Context, int, time.Duration, error) { panic("tick") }
+func (panicker) OnError(context.Context, int, error)               { panic("error") }
+func (panicker) OnStop(error)                                      { panic("stop") }
+
+// TestWithObservers tests that every observer receives the events in order, despite a panicking one
+func TestWithObservers(t *testing.T) {
+	var events []string
+	task := ticker.NewIndexed(func(n int) error {
+		if n == 2 {
+			return errors.New("task error")
+		}
+		return nil
+	})
+	err := task.Run(context.Background(), time.Millisecond,
+		ticker.WithLimit(2),
+		ticker.WithStopOnError(false),
+		ticker.WithObservers(recorder{"a", &events}, panicker{}),
+		ticker.WithObservers(nil, recorder{"b", &events}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "[a:tick1 b:tick1 a:tick2 b:tick2 a:error2 b:error2 a:stop(<nil>) b:stop(<nil>)]"
+	if got := fmt.Sprint(events); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
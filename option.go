@@ -1,5 +1,15 @@
 package ticker
 
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
 // Option represents a configuration option for the ticker.
 // It is used to modify the behavior of a Task when running.
 type Option interface {
@@ -8,20 +18,282 @@ type Option interface {
 
 // config holds the configuration for a ticker.
 type config struct {
-	Immediate bool
-	Limit     int
+	Burst    int
+	Limit    int
+	OnError  func(error) error
+	Backoff  *backoff
+	Jitter   float64
+	Recover  func(any) error
+	Timeout  time.Duration
+	Interval func(error) time.Duration
+
+	JitteredBackoff *jitteredBackoff
+	ErrorBackoff    time.Duration
+	Adaptive        *adaptive
+
+	MaxDuration   time.Duration
+	InitialJitter time.Duration
+	Shutdown      time.Duration
+	StartDelay    time.Duration
+	GracePeriod   time.Duration
+	ExecBudget    time.Duration
+	EndTime       time.Time
+	StopChan      <-chan struct{}
+	StopSignal    <-chan struct{}
+	Trigger       <-chan struct{}
+	DeadlineError *deadlineError
+	CtxErrAsNil   bool
+	Align         bool
+	Epoch         time.Time
+	AlignTol      time.Duration
+	WallClock     bool
+	Retry         *retry
+	StopOnError   bool
+	MaxErrors     int
+	MaxConsErrors int
+	Health        int
+	ReturnLast    bool
+	RepeatLast    bool
+	Decider       func(Decision) bool
+	ContinueIf    any
+	Values        []contextValue
+	ExecID        func(int) string
+	Middleware    []Middleware
+
+	PerTickContext func(context.Context) (context.Context, context.CancelFunc)
+	ContextWrapper func(context.Context, int) (context.Context, func(error))
+
+	SkipIfRunning bool
+	Async         bool
+	LockOSThread  bool
+	FailFastFirst bool
+	PanicAsError  bool
+	FirstInterval *time.Duration
+	MinInterval   *time.Duration
+	Gate          func() bool
+	Window        *activeWindow
+	RateLimiter   RateLimiter
+	Semaphore     chan struct{}
+	CatchUp       bool
+	MaxCatchUp    int
+	FixedDelay    bool
+	Spin          bool
+	FinalTick     bool
+	Concurrency   int
+
+	OnStart    func()
+	OnStop     func(error)
+	OnLimit    func(int)
+	PreTick    func(int) error
+	BeforeTick func(int)
+	AfterTick  func(int, error, time.Duration)
+
+	LagObserver func(scheduled, actual time.Time, lag time.Duration)
+	TraceRegion string
+	Saturation  func(time.Duration)
+	StatsReport *statsInterval
+
+	Logger    *slog.Logger
+	Observer  func(time.Duration, error)
+	Observers []Observer
+	ErrorChan chan<- error
+	Results   any
+	Name      string
+	TickError bool
+
+	// Clock provides the current time and timers.
+	Clock Clock
+
+	// Rand is the source of randomness used for jitter, guarded by RandMu.
+	// It is set by WithSeed, or created on first use if not set.
+	Rand   *rand.Rand
+	RandMu sync.Mutex
+
+	// Attempts is the number of consecutive failures, for WithJitteredBackoff.
+	Attempts int
+}
+
+// validate reports whether the configuration is consistent.
+func (c *config) validate() error {
+	if b := c.Backoff; b != nil {
+		if b.min <= 0 || b.min > b.max || b.factor <= 1 || b.decays && b.decay <= 1 {
+			return ErrInvalidBackoff
+		}
+	}
+	if b := c.JitteredBackoff; b != nil {
+		if b.base <= 0 || b.base > b.max || b.strategy < FullJitter || b.strategy > Decorrelated {
+			return ErrInvalidBackoff
+		}
+		if c.Backoff != nil {
+			return fmt.Errorf("%w: WithBackoff and WithJitteredBackoff", ErrConflictingOptions)
+		}
+	}
+	if c.ErrorBackoff != 0 {
+		if c.ErrorBackoff < 0 {
+			return ErrInvalidBackoff
+		}
+		if c.Backoff != nil || c.JitteredBackoff != nil {
+			return fmt.Errorf("%w: WithErrorBackoff and another backoff", ErrConflictingOptions)
+		}
+	}
+	if a := c.Adaptive; a != nil {
+		if !(a.multiplier > 0) || math.IsInf(a.multiplier, 1) || a.min <= 0 || a.min > a.max {
+			return fmt.Errorf("%w: invalid adaptive interval", ErrInvalidArgument)
+		}
+		if c.Backoff != nil || c.JitteredBackoff != nil || c.ErrorBackoff != 0 {
+			return fmt.Errorf("%w: WithAdaptiveToLatency and a backoff", ErrConflictingOptions)
+		}
+	}
+	if c.Burst < 0 {
+		return ErrInvalidBurst
+	}
+	if !(c.Jitter >= 0 && c.Jitter <= 1) {
+		return ErrInvalidJitter
+	}
+	if c.FirstInterval != nil && *c.FirstInterval <= 0 {
+		return ErrNonPositiveInterval
+	}
+	if c.MinInterval != nil && *c.MinInterval <= 0 {
+		return ErrNonPositiveInterval
+	}
+	if c.AlignTol < 0 {
+		return fmt.Errorf("%w: negative align tolerance", ErrInvalidArgument)
+	}
+	if c.StartDelay < 0 {
+		return fmt.Errorf("%w: negative start delay", ErrInvalidArgument)
+	}
+	if c.StatsReport != nil && c.StatsReport.d <= 0 {
+		return fmt.Errorf("%w: non-positive stats interval", ErrInvalidArgument)
+	}
+	if c.InitialJitter < 0 {
+		return fmt.Errorf("%w: negative initial jitter", ErrInvalidArgument)
+	}
+	if c.Shutdown < 0 {
+		return fmt.Errorf("%w: negative shutdown timeout", ErrInvalidArgument)
+	}
+	if c.GracePeriod < 0 {
+		return fmt.Errorf("%w: negative grace period", ErrInvalidArgument)
+	}
+	if c.GracePeriod > 0 && c.FailFastFirst {
+		return fmt.Errorf("%w: WithGracePeriod and WithFailFastFirst", ErrConflictingOptions)
+	}
+	if w := c.Window; w != nil {
+		if w.start < 0 || w.start >= 24*time.Hour || w.end < 0 || w.end >= 24*time.Hour || w.start == w.end {
+			return fmt.Errorf("%w: invalid active window", ErrInvalidArgument)
+		}
+	}
+	if c.Health < 0 {
+		return fmt.Errorf("%w: negative health threshold", ErrInvalidArgument)
+	}
+	if r := c.Retry; r != nil {
+		if r.attempts < 1 || r.delay < 0 {
+			return ErrInvalidRetry
+		}
+	}
+	if c.FixedDelay && c.CatchUp {
+		return fmt.Errorf("%w: WithFixedDelay and WithCatchUp", ErrConflictingOptions)
+	}
+	if c.Async && c.SkipIfRunning {
+		return fmt.Errorf("%w: WithAsync and WithSkipIfRunning", ErrConflictingOptions)
+	}
+	if c.LockOSThread && (c.Async || c.SkipIfRunning || c.Shutdown > 0) {
+		return fmt.Errorf("%w: WithLockOSThread and executions on other goroutines", ErrConflictingOptions)
+	}
+	return nil
+}
+
+// interval returns the interval to use after an execution that returned err after
+// running for took, given the base interval d and the current interval cur.
+func (c *config) interval(d, cur time.Duration, err error, took time.Duration) time.Duration {
+	if c.Backoff != nil {
+		switch {
+		case err != nil:
+			cur = c.Backoff.next(cur)
+		case c.Backoff.decays:
+			cur = c.Backoff.prev(cur)
+		default:
+			cur = d
+		}
+	}
+	if c.JitteredBackoff != nil {
+		if err != nil {
+			cur = c.JitteredBackoff.next(c, cur)
+			c.Attempts++
+		} else {
+			cur = d
+			c.Attempts = 0
+		}
+	}
+	if c.ErrorBackoff != 0 {
+		if err != nil {
+			cur = max(d, min(2*min(cur, c.ErrorBackoff), c.ErrorBackoff))
+		} else {
+			cur = d
+		}
+	}
+	if c.Adaptive != nil {
+		cur = c.Adaptive.next(took)
+	}
+	if c.Interval != nil {
+		if next := c.Interval(err); next > 0 {
+			cur = next
+		}
+	}
+	return cur
+}
+
+// floor returns iv raised to the minimum interval of WithMinInterval, if any.
+func (c *config) floor(iv time.Duration) time.Duration {
+	if c.MinInterval != nil && iv < *c.MinInterval {
+		return *c.MinInterval
+	}
+	return iv
+}
+
+// jitter returns iv randomized by up to ±c.Jitter of iv.
+func (c *config) jitter(iv time.Duration) time.Duration {
+	if c.Jitter == 0 {
+		return iv
+	}
+	return iv + time.Duration((2*c.random()-1)*c.Jitter*float64(iv))
+}
+
+// random returns a pseudo-random number in [0.0, 1.0) from c.Rand.
+func (c *config) random() float64 {
+	c.RandMu.Lock()
+	defer c.RandMu.Unlock()
+	if c.Rand == nil {
+		// Seeded from the global source, which is seeded randomly, so that tickers
+		// started at the same time do not jitter in lockstep.
+		c.Rand = rand.New(rand.NewSource(rand.Int63()))
+	}
+	return c.Rand.Float64()
 }
 
 // WithImmediate returns an Option to set whether the task should be executed immediately
 // before starting the ticker.
+// WithImmediate(true) is equivalent to WithBurst(1), and WithImmediate(false) to WithBurst(0).
 func WithImmediate(v bool) Option {
-	return immediate(v)
+	if v {
+		return burst(1)
+	}
+	return burst(0)
 }
 
-type immediate bool
+// WithBurst returns an Option to execute the task n times back-to-back before starting
+// the ticker, for example to warm up.
+//
+// The executions of the burst count toward WithLimit and stop the ticker on error like
+// any other. The options that refer to the immediate execution, such as WithGate, apply
+// to each of them. Run returns ErrInvalidBurst if n is negative.
+func WithBurst(n int) Option {
+	return burst(n)
+}
+
+type burst int
 
-func (o immediate) apply(c *config) {
-	c.Immediate = bool(o)
+func (o burst) apply(c *config) {
+	c.Burst = int(o)
 }
 
 // WithLimit returns an Option to set the maximum number of times the task should be executed.
@@ -37,3 +309,1233 @@ type limit int
 func (o limit) apply(c *config) {
 	c.Limit = int(o)
 }
+
+// WithOnLimitReached returns an Option to set a callback that is called when the ticker
+// stops because it reached the limit of executions, with the total number of executions.
+//
+// It signals that all the planned executions have completed, which Run does not tell
+// apart from other clean stops such as WithStopChan. It is not called when the ticker
+// stops for any other reason, such as an error, the context or Handle.Stop, even after
+// the last execution. A limit set by Handle.SetLimit counts as well. With Until, it is
+// called when the attempts run out without success. With WithAsync, Run waits for the
+// executions in flight before it calls the callback. The callback runs before the one
+// of WithOnStop.
+func WithOnLimitReached(fn func(total int)) Option {
+	return onLimit(fn)
+}
+
+type onLimit func(int)
+
+func (o onLimit) apply(c *config) {
+	c.OnLimit = o
+}
+
+// WithOnError returns an Option to set a callback that decides what happens when the task
+// returns an error.
+//
+// The callback receives the error returned by the task. If it returns nil, the ticker
+// continues to the next tick; otherwise the ticker stops and Run returns the error
+// returned by the callback. A failed execution still counts toward WithLimit.
+func WithOnError(fn func(error) error) Option {
+	return onError(fn)
+}
+
+type onError func(error) error
+
+func (o onError) apply(c *config) {
+	c.OnError = o
+}
+
+// WithBackoff returns an Option to grow the interval while the task keeps failing.
+//
+// When the task returns an error, the current interval is multiplied by factor and
+// clamped to the range [min, max]. The next successful execution resets the interval
+// back to the base interval given to Run.
+//
+// Backoff is only meaningful when errors do not stop the ticker, e.g. with WithOnError.
+// Every execution, failed or not, counts toward WithLimit. An error from the immediate
+// execution requested by WithImmediate already applies to the first interval.
+//
+// Run returns ErrInvalidBackoff if min is not positive, min is greater than max,
+// or factor is not greater than 1.
+func WithBackoff(min, max time.Duration, factor float64) Option {
+	return &backoff{min: min, max: max, factor: factor}
+}
+
+// WithBackoffDecay returns an Option to grow the interval while the task keeps failing,
+// and to shrink it gradually as the task succeeds again.
+//
+// Like WithBackoff, an error multiplies the current interval by growth, but a successful
+// execution divides it by decay instead of resetting it, both clamped to the range
+// [min, max]. The interval thus converges toward min over successive successes, which
+// smooths it when failures are intermittent.
+//
+// Run returns ErrInvalidBackoff if min is not positive, min is greater than max, or
+// growth or decay is not greater than 1.
+func WithBackoffDecay(min, max time.Duration, growth, decay float64) Option {
+	return &backoff{min: min, max: max, factor: growth, decay: decay, decays: true}
+}
+
+type backoff struct {
+	min, max time.Duration
+	factor   float64
+
+	// decay divides the interval after a successful execution if decays is set.
+	// Otherwise, a successful execution resets the interval to the base interval.
+	decay  float64
+	decays bool
+}
+
+func (o *backoff) apply(c *config) {
+	c.Backoff = o
+}
+
+// WithErrorBackoff returns an Option to delay the next execution after an error, and
+// only after an error.
+//
+// Each consecutive error doubles the interval, starting from the base interval given to
+// Run, up to max. The first successful execution snaps the interval back to the base
+// interval, and the ticks that follow are exactly the base interval apart, as if no
+// error had occurred: the happy path keeps its precise cadence, and the delays do not
+// accumulate as drift. A max less than the base interval leaves the interval unchanged.
+//
+// Run returns ErrInvalidBackoff if max is negative, and ErrConflictingOptions if
+// WithBackoff or WithJitteredBackoff is also set. A max of zero disables it.
+func WithErrorBackoff(max time.Duration) Option {
+	return errorBackoff(max)
+}
+
+type errorBackoff time.Duration
+
+func (o errorBackoff) apply(c *config) {
+	c.ErrorBackoff = time.Duration(o)
+}
+
+// WithAdaptiveToLatency returns an Option to adapt the interval to how long the task
+// takes, so that polling a slow backend naturally backs off.
+//
+// After each execution, the interval is set to multiplier times the duration of the
+// execution, clamped to the range [min, max]. For example, with a multiplier of 5, the
+// task runs about a sixth of the time. The base interval given to Run applies until the
+// first execution, and WithInterval, if any, takes precedence.
+//
+// Run returns an error wrapping ErrInvalidArgument if multiplier is not positive, min
+// is not positive or min is greater than max, and ErrConflictingOptions if WithBackoff
+// or WithJitteredBackoff is also set.
+func WithAdaptiveToLatency(multiplier float64, min, max time.Duration) Option {
+	return &adaptive{multiplier: multiplier, min: min, max: max}
+}
+
+type adaptive struct {
+	multiplier float64
+	min, max   time.Duration
+}
+
+func (o *adaptive) apply(c *config) {
+	c.Adaptive = o
+}
+
+// next returns the interval following an execution that took took.
+func (o *adaptive) next(took time.Duration) time.Duration {
+	iv := float64(took) * o.multiplier
+	if iv >= float64(o.max) {
+		return o.max
+	}
+	return max(o.min, time.Duration(iv))
+}
+
+// next returns the interval following cur after a failed execution.
+func (o *backoff) next(cur time.Duration) time.Duration {
+	return o.clamp(float64(cur) * o.factor)
+}
+
+// prev returns the interval following cur after a successful execution with decay.
+func (o *backoff) prev(cur time.Duration) time.Duration {
+	return o.clamp(float64(cur) / o.decay)
+}
+
+// clamp returns the interval next clamped to the range [min, max].
+func (o *backoff) clamp(next float64) time.Duration {
+	if next < float64(o.min) {
+		return o.min
+	}
+	if next > float64(o.max) {
+		return o.max
+	}
+	return time.Duration(next)
+}
+
+// JitterStrategy is the algorithm of WithJitteredBackoff to randomize the interval
+// after a failure.
+type JitterStrategy int
+
+const (
+	// FullJitter picks the interval uniformly between 0 and the exponential backoff
+	// min(max, base * 2^attempt).
+	FullJitter JitterStrategy = iota
+
+	// EqualJitter keeps half of the exponential backoff and picks the other half
+	// uniformly, so the interval is never shorter than half the backoff.
+	EqualJitter
+
+	// Decorrelated picks the interval uniformly between base and three times the
+	// previous interval, capped at max.
+	Decorrelated
+)
+
+// String returns the name of the strategy.
+func (s JitterStrategy) String() string {
+	switch s {
+	case FullJitter:
+		return "FullJitter"
+	case EqualJitter:
+		return "EqualJitter"
+	case Decorrelated:
+		return "Decorrelated"
+	}
+	return fmt.Sprintf("JitterStrategy(%d)", int(s))
+}
+
+// WithJitteredBackoff returns an Option to back off with randomized intervals while the
+// task keeps failing, per the strategy, as popularized for retries by AWS.
+//
+// attempt counts the consecutive failures from 0, and the next successful execution
+// resets the interval back to the base interval given to Run. It is meant for polling
+// until success, as with Until, where many clients should not retry in lockstep. The
+// randomness can be made reproducible with WithSeed.
+//
+// Run returns ErrInvalidBackoff if base is not positive, base is greater than max, or
+// strategy is unknown, and ErrConflictingOptions if WithBackoff is also set.
+func WithJitteredBackoff(base, max time.Duration, strategy JitterStrategy) Option {
+	return &jitteredBackoff{base: base, max: max, strategy: strategy}
+}
+
+type jitteredBackoff struct {
+	base, max time.Duration
+	strategy  JitterStrategy
+}
+
+func (o *jitteredBackoff) apply(c *config) {
+	c.JitteredBackoff = o
+}
+
+// next returns the interval following cur after a failed execution.
+func (o *jitteredBackoff) next(c *config, cur time.Duration) time.Duration {
+	base, max := float64(o.base), float64(o.max)
+	switch o.strategy {
+	case EqualJitter:
+		exp := math.Min(max, base*math.Exp2(float64(c.Attempts)))
+		return time.Duration(exp/2 + c.random()*exp/2)
+	case Decorrelated:
+		prev := base
+		if c.Attempts > 0 {
+			prev = math.Max(base, float64(cur))
+		}
+		return time.Duration(math.Min(max, base+c.random()*(3*prev-base)))
+	}
+	exp := math.Min(max, base*math.Exp2(float64(c.Attempts)))
+	return time.Duration(c.random() * exp)
+}
+
+// WithJitter returns an Option to randomize each interval by up to ±frac of the interval.
+// For example, a frac of 0.1 with an interval d gives intervals between 0.9d and 1.1d.
+//
+// Jitter helps to avoid many tickers with the same interval firing in lockstep.
+// Run returns ErrInvalidJitter if frac is outside of the range [0, 1].
+func WithJitter(frac float64) Option {
+	return jitter(frac)
+}
+
+type jitter float64
+
+func (o jitter) apply(c *config) {
+	c.Jitter = float64(o)
+}
+
+// WithInitialJitter returns an Option to delay the first tick by a random duration in
+// [0, max), to spread the load of many tickers started at the same time, such as on
+// the instances of a deployment.
+//
+// Only the first tick is delayed: the following ticks keep the exact interval from it,
+// unlike WithJitter, which randomizes every interval. The immediate executions of
+// WithImmediate and WithBurst are not delayed. WithSeed makes the delay reproducible.
+// A negative max is reported as an error wrapping ErrInvalidArgument.
+func WithInitialJitter(max time.Duration) Option {
+	return initialJitter(max)
+}
+
+type initialJitter time.Duration
+
+func (o initialJitter) apply(c *config) {
+	c.InitialJitter = time.Duration(o)
+}
+
+// WithSeed returns an Option to seed the randomization of WithJitter, WithInitialJitter
+// and WithJitteredBackoff, so that the jittered intervals are reproducible, for example
+// in tests.
+//
+// Each run starts from the seed, so runs with the same seed and options produce the
+// same sequence of intervals. Without WithSeed, the source is seeded randomly. It has
+// no effect unless WithJitter, WithInitialJitter or WithJitteredBackoff is set.
+func WithSeed(seed int64) Option {
+	return seedOption(seed)
+}
+
+type seedOption int64
+
+func (o seedOption) apply(c *config) {
+	c.Rand = rand.New(rand.NewSource(int64(o)))
+}
+
+// WithRecover returns an Option to recover from a panic in the task.
+//
+// When the task panics, fn is called with the recovered value. If fn returns a non-nil
+// error, the ticker stops and Run returns that error; otherwise the ticker continues
+// to the next tick. The error returned by fn is not passed to the WithOnError callback.
+func WithRecover(fn func(any) error) Option {
+	return recoverer(fn)
+}
+
+type recoverer func(any) error
+
+func (o recoverer) apply(c *config) {
+	c.Recover = o
+}
+
+// WithTimeout returns an Option to bound each execution of the task.
+//
+// Each execution receives a context derived with context.WithTimeout, which is
+// canceled once the timeout elapses. Whether a timed out execution is an error is up
+// to the task; use NewContext to observe the context. If the task returns an error after
+// the timeout elapsed, the error wraps ErrTickTimeout in addition to the error of the
+// task, so that errors.Is(err, ErrTickTimeout) tells slow executions apart. An error
+// returned before the timeout is left as is. A non-positive value means no timeout.
+//
+// A timeout shorter than the interval ensures that an execution finishes before the
+// next tick is due. With a longer timeout, a slow execution delays the following tick,
+// and the ticks missed in the meantime are dropped.
+func WithTimeout(d time.Duration) Option {
+	return timeout(d)
+}
+
+type timeout time.Duration
+
+func (o timeout) apply(c *config) {
+	c.Timeout = time.Duration(o)
+}
+
+// WithInterval returns an Option to adjust the interval dynamically.
+//
+// fn is called after each execution, including the immediate one requested by
+// WithImmediate, with the error returned by the task. It returns the interval until
+// the next tick; a non-positive value keeps the current interval. Until the first
+// call, the interval is the one given to Run. When combined with WithBackoff, a
+// positive value returned by fn takes precedence over the backoff interval.
+func WithInterval(fn func(lastErr error) time.Duration) Option {
+	return interval(fn)
+}
+
+type interval func(error) time.Duration
+
+func (o interval) apply(c *config) {
+	c.Interval = o
+}
+
+// WithMaxDuration returns an Option to cap the total run time of the ticker.
+//
+// Once d has elapsed since the ticker started, Run returns nil regardless of how many
+// executions happened. An execution in progress is not interrupted. Combined with
+// WithLimit, whichever is reached first stops the ticker.
+// A non-positive value means no cap.
+func WithMaxDuration(d time.Duration) Option {
+	return maxDuration(d)
+}
+
+type maxDuration time.Duration
+
+func (o maxDuration) apply(c *config) {
+	c.MaxDuration = time.Duration(o)
+}
+
+// WithStartDelay returns an Option to wait d once before the ticker starts its schedule,
+// for example to stagger many services that boot at the same time.
+//
+// Unlike WithFirstInterval, the delay comes before everything else: the immediate
+// executions of WithImmediate and WithBurst happen after it, and the schedule, including
+// its alignment, starts when it ends. The delay is randomized by WithJitter, if any. It
+// is interrupted by the context, in which case Run returns the context error without
+// executing the task, and by the other stop conditions. WithMaxDuration counts it.
+// Run returns an error wrapping ErrInvalidArgument if d is negative.
+func WithStartDelay(d time.Duration) Option {
+	return startDelay(d)
+}
+
+type startDelay time.Duration
+
+func (o startDelay) apply(c *config) {
+	c.StartDelay = time.Duration(o)
+}
+
+// WithTotalExecBudget returns an Option to stop the ticker once the total time spent
+// executing the task exceeds d, for example to bound the work of a background
+// maintenance loop over the lifetime of a process.
+//
+// The durations of the executions, as measured for WithAfterTick, are summed, and once
+// the sum exceeds d, Run returns nil without executing the task again. The execution that
+// exceeds the budget is not interrupted. Unlike WithMaxDuration, the time between the
+// executions does not count. It composes with WithMaxDuration and WithLimit: whichever
+// is reached first stops the ticker. A non-positive value means no budget.
+func WithTotalExecBudget(d time.Duration) Option {
+	return execBudget(d)
+}
+
+type execBudget time.Duration
+
+func (o execBudget) apply(c *config) {
+	c.ExecBudget = time.Duration(o)
+}
+
+// WithOnStart returns an Option to set a callback that is called once when the ticker starts.
+//
+// It is not called if Run fails because of invalid arguments.
+func WithOnStart(fn func()) Option {
+	return onStart(fn)
+}
+
+type onStart func()
+
+func (o onStart) apply(c *config) {
+	c.OnStart = o
+}
+
+// WithOnStop returns an Option to set a callback that is called once when the ticker stops.
+//
+// The callback receives the error Run returns, including context errors, or nil.
+// It is called exactly once in all exit paths of a started ticker, but not if Run fails
+// because of invalid arguments.
+func WithOnStop(fn func(error)) Option {
+	return onStop(fn)
+}
+
+type onStop func(error)
+
+func (o onStop) apply(c *config) {
+	c.OnStop = o
+}
+
+// WithPreTick returns an Option to set a check that is called before each execution and
+// can abort the ticker.
+//
+// n is the 1-based execution counter the execution would have. If fn returns nil, the
+// task is executed as usual. Otherwise, unlike WithGate, which skips the tick, the ticker
+// stops without executing the task, and Run returns an error wrapping both
+// ErrPreTickAbort and the error of fn, regardless of WithOnError or WithStopOnError.
+// It is useful to fail deliberately, for example when a feature flag is turned off or a
+// quota is exhausted.
+func WithPreTick(fn func(n int) error) Option {
+	return preTick(fn)
+}
+
+type preTick func(int) error
+
+func (o preTick) apply(c *config) {
+	c.PreTick = o
+}
+
+// WithBeforeTick returns an Option to set a callback that is called before each execution.
+//
+// n is the 1-based execution counter.
+func WithBeforeTick(fn func(n int)) Option {
+	return beforeTick(fn)
+}
+
+type beforeTick func(int)
+
+func (o beforeTick) apply(c *config) {
+	c.BeforeTick = o
+}
+
+// WithAfterTick returns an Option to set a callback that is called after each execution.
+//
+// n is the 1-based execution counter, err is the error returned by the task and took is
+// the time the execution took.
+func WithAfterTick(fn func(n int, err error, took time.Duration)) Option {
+	return afterTick(fn)
+}
+
+type afterTick func(int, error, time.Duration)
+
+func (o afterTick) apply(c *config) {
+	c.AfterTick = o
+}
+
+// WithHealth returns an Option to track the health of the ticker from the outcomes of
+// its executions, for example as a readiness signal.
+//
+// The ticker becomes unhealthy after threshold consecutive failed executions, and healthy
+// again after one successful execution. It starts healthy. Handle.Healthy reports the
+// state. A threshold of 0 disables the tracking, which is the default, and Run returns an
+// error wrapping ErrInvalidArgument if threshold is negative.
+func WithHealth(threshold int) Option {
+	return health(threshold)
+}
+
+type health int
+
+func (o health) apply(c *config) {
+	c.Health = int(o)
+}
+
+// WithAlign returns an Option to set whether the first tick should be aligned to a
+// wall-clock boundary.
+//
+// When enabled, the first tick fires at the next multiple of the interval since the
+// zero time, and the following ticks fire every interval after that. For example, with
+// an interval of time.Minute, a ticker started at 10:00:37 first fires at 10:01:00.
+//
+// WithImmediate takes precedence: the immediate execution happens right away, and the
+// first scheduled tick is still aligned. The first tick is not jittered.
+func WithAlign(v bool) Option {
+	return align(v)
+}
+
+type align bool
+
+func (o align) apply(c *config) {
+	c.Align = bool(o)
+	c.Epoch = time.Time{}
+}
+
+// WithAlignTo returns an Option to align the ticks to a custom epoch instead of the
+// zero time.
+//
+// The first tick fires at the next epoch + k*d after the start, where d is the interval
+// and k is an integer, and the following ticks fire every interval after that. For
+// example, with an interval of 15 minutes and an epoch at 09:00:05, the ticks fire at
+// 5 seconds past :00, :15, :30 and :45, on every machine regardless of its start time.
+//
+// k may be negative, so an epoch in the future aligns the ticks to the same instants
+// without delaying the first tick until the epoch. Otherwise WithAlignTo behaves like
+// WithAlign(true), which resets the epoch to the zero time.
+func WithAlignTo(epoch time.Time) Option {
+	return alignTo(epoch)
+}
+
+type alignTo time.Time
+
+func (o alignTo) apply(c *config) {
+	c.Align = true
+	c.Epoch = time.Time(o)
+}
+
+// WithAlignTolerance returns an Option to treat times within d of an aligned time as
+// on that time, for WithAlign, WithAlignTo and WithWallClock.
+//
+// Timers are not exact, and the wall clock may be adjusted while the ticker runs. A
+// ticker started, or a tick of WithWallClock rescheduled, a little after an aligned time
+// would otherwise wait for the next one, a full interval later, and a tick that fires a
+// little early would be followed by another tick at the aligned time it was meant for.
+// With a tolerance, a ticker started within d after an aligned time fires right away, and
+// the tick following a tick that fires within d before an aligned time is scheduled an
+// interval after that aligned time. d should be much smaller than the interval; the
+// default is 0, with no tolerance. Run returns an error wrapping ErrInvalidArgument if d
+// is negative.
+func WithAlignTolerance(d time.Duration) Option {
+	return alignTolerance(d)
+}
+
+type alignTolerance time.Duration
+
+func (o alignTolerance) apply(c *config) {
+	c.AlignTol = time.Duration(o)
+}
+
+// WithWallClock returns an Option to schedule the ticks by the wall clock.
+//
+// By default, the ticks follow the monotonic clock: each tick is due one interval after
+// the previous one, regardless of changes to the system clock. Over a long run, the ticks
+// may then drift relative to the wall clock, and a step of the system clock, for example
+// by NTP, shifts them for good.
+//
+// When enabled, the ticks are aligned like WithAlign or WithAlignTo, and after each tick
+// the next one is recomputed from the current wall clock time as the next aligned time.
+// A step of the system clock is corrected from the tick following it: the pending tick
+// still fires after the delay computed before the step, and the next one is realigned,
+// skipping the aligned times stepped over. WithJitter, WithCatchUp and WithFixedDelay have
+// no effect, and the ticks follow the base interval rather than the current one.
+func WithWallClock(enabled bool) Option {
+	return wallClock(enabled)
+}
+
+type wallClock bool
+
+func (o wallClock) apply(c *config) {
+	c.WallClock = bool(o)
+}
+
+// WithRetry returns an Option to retry a failed execution within the same tick.
+//
+// When the task returns an error, it is re-invoked up to attempts more times, waiting
+// delay between tries. Only if all tries fail does the error reach the normal error
+// handling, such as WithOnError. Retries stop early when the context is done.
+// A tick counts as one execution regardless of the number of tries.
+//
+// Run returns ErrInvalidRetry if attempts is less than 1 or delay is negative.
+func WithRetry(attempts int, delay time.Duration) Option {
+	return &retry{attempts: attempts, delay: delay}
+}
+
+type retry struct {
+	attempts int
+	delay    time.Duration
+}
+
+func (o *retry) apply(c *config) {
+	c.Retry = o
+}
+
+// WithClock returns an Option to set the Clock used to schedule ticks and measure time.
+//
+// It is intended for tests; see the clocktest package for a fake Clock.
+// The per-execution timeout set by WithTimeout always uses real time.
+// A nil Clock means the real clock.
+func WithClock(clock Clock) Option {
+	return clockOption{clock}
+}
+
+type clockOption struct {
+	clock Clock
+}
+
+func (o clockOption) apply(c *config) {
+	if o.clock == nil {
+		c.Clock = realClock{}
+		return
+	}
+	c.Clock = o.clock
+}
+
+// WithStopOnError returns an Option to set whether a task error stops the ticker.
+//
+// The default is true. When false, task errors are ignored and the ticker continues
+// until the context is canceled or the limit is reached; use RunStats to see how many
+// executions failed. WithOnError, if set, takes precedence over this option.
+func WithStopOnError(v bool) Option {
+	return stopOnError(v)
+}
+
+type stopOnError bool
+
+func (o stopOnError) apply(c *config) {
+	c.StopOnError = bool(o)
+}
+
+// WithContextValue returns an Option to attach a value to the context observed by the task.
+//
+// The context given to Run is derived with context.WithValue before the first execution,
+// so every execution of a context-aware task sees the value, e.g. a scoped logger or a
+// request ID. The key must satisfy the requirements of context.WithValue.
+// Multiple WithContextValue options are layered in the order given, so a later option
+// shadows an earlier one with the same key.
+func WithContextValue(key, value any) Option {
+	return contextValue{key: key, value: value}
+}
+
+type contextValue struct {
+	key, value any
+}
+
+func (o contextValue) apply(c *config) {
+	c.Values = append(c.Values, o)
+}
+
+// WithSkipIfRunning returns an Option to run executions concurrently with the schedule
+// and skip ticks while the previous execution is still running.
+//
+// By default, each execution blocks the ticker, so a slow execution delays the following
+// ticks. When enabled, each execution runs in its own goroutine and the ticks keep firing
+// on schedule; a tick that fires while an execution is in flight is skipped rather than
+// queued, and counted in Stats.Skipped. Skipped ticks do not count toward WithLimit.
+// The immediate execution requested by WithImmediate still runs synchronously.
+//
+// When the ticker stops, Run waits for the execution in flight to finish.
+func WithSkipIfRunning(v bool) Option {
+	return skipIfRunning(v)
+}
+
+type skipIfRunning bool
+
+func (o skipIfRunning) apply(c *config) {
+	c.SkipIfRunning = bool(o)
+}
+
+// WithFirstInterval returns an Option to set the delay before the first tick separately
+// from the interval between the following ticks.
+//
+// With WithImmediate, the immediate execution happens right away and the first tick
+// fires d after it. WithFirstInterval takes precedence over WithAlign, and the first
+// interval is not jittered.
+// Run returns ErrNonPositiveInterval if d is not positive.
+func WithFirstInterval(d time.Duration) Option {
+	return firstInterval(d)
+}
+
+type firstInterval time.Duration
+
+func (o firstInterval) apply(c *config) {
+	d := time.Duration(o)
+	c.FirstInterval = &d
+}
+
+// WithMinInterval returns an Option to set a lower bound on the interval between ticks,
+// to guard against a hot loop.
+//
+// Any interval shorter than d, whether computed by WithInterval, WithBackoff,
+// WithJitteredBackoff or WithJitter, or given to Run or Handle.Reset, is raised to d:
+// the bound takes precedence over whatever a dynamic callback returns. It does not
+// apply to the first interval set by WithFirstInterval, nor to aligned or cron
+// schedules.
+// Run returns ErrNonPositiveInterval if d is not positive.
+func WithMinInterval(d time.Duration) Option {
+	return minInterval(d)
+}
+
+type minInterval time.Duration
+
+func (o minInterval) apply(c *config) {
+	d := time.Duration(o)
+	c.MinInterval = &d
+}
+
+// WithGate returns an Option to set a condition that is checked right before each execution.
+//
+// If fn returns false, the tick is skipped; the schedule keeps running and the skipped
+// tick does not count toward WithLimit. This also applies to the immediate execution
+// requested by WithImmediate: if it is gated out, it is not executed and not counted.
+func WithGate(fn func() bool) Option {
+	return gate(fn)
+}
+
+type gate func() bool
+
+func (o gate) apply(c *config) {
+	c.Gate = o
+}
+
+// WithActiveWindow returns an Option to execute the task only during a window of time
+// every day, such as from 08:00 to 20:00.
+//
+// start and end are times of day as read on a clock in loc, expressed as offsets from
+// midnight, such as 8*time.Hour for 08:00; a nil loc means the location of the clock.
+// The window includes start and excludes end. If start is after end, the window wraps
+// past midnight, so that 22:00 to 06:00 covers the night. As the times are read on the
+// clock, the window follows daylight saving time transitions: a window starting at a
+// time skipped by a transition starts right after it.
+//
+// Outside the window, ticks are skipped like with WithGate: the schedule keeps running
+// and the skipped ticks do not count toward WithLimit. Both conditions must hold to
+// execute the task when combined with WithGate. start and end must be within [0, 24h)
+// and differ; otherwise, it is reported as an error wrapping ErrInvalidArgument.
+func WithActiveWindow(start, end time.Duration, loc *time.Location) Option {
+	return &activeWindow{start: start, end: end, loc: loc}
+}
+
+type activeWindow struct {
+	start, end time.Duration
+	loc        *time.Location
+}
+
+func (o *activeWindow) apply(c *config) {
+	c.Window = o
+}
+
+// contains reports whether t is within the window.
+func (w *activeWindow) contains(t time.Time) bool {
+	if w.loc != nil {
+		t = t.In(w.loc)
+	}
+	h, m, sec := t.Clock()
+	tod := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
+	if w.start < w.end {
+		return w.start <= tod && tod < w.end
+	}
+	return w.start <= tod || tod < w.end
+}
+
+// WithEndTime returns an Option to stop the ticker at an absolute time.
+//
+// Once the clock passes t, Run returns nil, even between ticks. An execution in progress
+// is not interrupted. If t has already passed when the ticker starts, Run returns nil
+// right away without executing the task, even with WithImmediate.
+// Combined with WithMaxDuration, whichever comes first stops the ticker.
+// The zero time means no end time.
+func WithEndTime(t time.Time) Option {
+	return endTime{t}
+}
+
+type endTime struct {
+	t time.Time
+}
+
+func (o endTime) apply(c *config) {
+	c.EndTime = o.t
+}
+
+// WithObserver returns an Option to set a callback that is called after every invocation
+// of the task with the time the invocation took and the error it returned.
+//
+// The measured time covers only the task itself, not the wait for the tick, so it is
+// suitable for a latency histogram. Each try of WithRetry is a separate invocation.
+// A panic recovered by WithRecover is observed with the error returned by the handler.
+func WithObserver(fn func(took time.Duration, err error)) Option {
+	return observer(fn)
+}
+
+type observer func(time.Duration, error)
+
+func (o observer) apply(c *config) {
+	c.Observer = o
+}
+
+// WithCatchUp returns an Option to set whether ticks missed during a long execution
+// should be caught up.
+//
+// By default, like time.Ticker, the ticks missed while an execution runs longer than the
+// interval are dropped. When enabled, the missed ticks fire back-to-back right away
+// before the ticker resumes its normal schedule. Use WithMaxCatchUp to bound the burst.
+// Caught up executions count toward WithLimit like any other.
+func WithCatchUp(v bool) Option {
+	return catchUp(v)
+}
+
+type catchUp bool
+
+func (o catchUp) apply(c *config) {
+	c.CatchUp = bool(o)
+}
+
+// WithMaxCatchUp returns an Option to cap the number of missed ticks fired back-to-back
+// by WithCatchUp. The ticks missed beyond the cap are dropped.
+// A non-positive value means no cap.
+func WithMaxCatchUp(n int) Option {
+	return maxCatchUp(n)
+}
+
+type maxCatchUp int
+
+func (o maxCatchUp) apply(c *config) {
+	c.MaxCatchUp = int(o)
+}
+
+// WithFixedDelay returns an Option to set whether the ticker waits a fixed delay after
+// each execution instead of ticking at a fixed rate.
+//
+// By default, like time.Ticker, ticks fire at a fixed rate, every interval since the
+// previous tick, so a slow execution is followed right away by the next one. When enabled,
+// the ticker waits the full interval after each execution completes, so executions never
+// pile up. With WithSkipIfRunning, executions do not block the ticker, so the delay is
+// measured from the start of each execution. Since no tick is ever missed, it conflicts
+// with WithCatchUp.
+func WithFixedDelay(v bool) Option {
+	return fixedDelay(v)
+}
+
+type fixedDelay bool
+
+func (o fixedDelay) apply(c *config) {
+	c.FixedDelay = bool(o)
+}
+
+// WithName returns an Option to name the ticker for identification in logs and errors.
+//
+// The name is included in the output of WithLogger, and a non-nil error returned by a
+// started ticker is wrapped as fmt.Errorf("ticker %q: %w", name, err), so errors.Is and
+// errors.As still match the underlying error. Errors about invalid arguments are not
+// wrapped. An empty name, the default, disables both.
+func WithName(name string) Option {
+	return nameOption(name)
+}
+
+type nameOption string
+
+func (o nameOption) apply(c *config) {
+	c.Name = string(o)
+}
+
+// WithStopChan returns an Option to stop the ticker when ch is closed.
+//
+// Unlike context cancellation, closing ch is a successful termination: Run returns nil.
+// An execution in progress is not interrupted. This helps to integrate with code that
+// signals shutdown by closing a channel rather than canceling a context.
+func WithStopChan(ch <-chan struct{}) Option {
+	return stopChan(ch)
+}
+
+type stopChan <-chan struct{}
+
+func (o stopChan) apply(c *config) {
+	c.StopChan = o
+}
+
+// WithStopSignal returns an Option to stop the ticker when ch receives a value or is
+// closed, and return ErrStopped.
+//
+// Unlike WithStopChan, the stop is reported as an error, which tells an externally
+// requested stop apart from a context cancellation or deadline. An execution in progress
+// is not interrupted, and a tick that fires together with the signal is not executed.
+func WithStopSignal(ch <-chan struct{}) Option {
+	return stopSignal(ch)
+}
+
+type stopSignal <-chan struct{}
+
+func (o stopSignal) apply(c *config) {
+	c.StopSignal = o
+}
+
+// WithFailFastFirst returns an Option to set whether an error on the first execution
+// always stops the ticker.
+//
+// When enabled, an error on the first execution, which is the immediate one with
+// WithImmediate, stops the ticker regardless of WithStopOnError and WithOnError, and Run
+// returns it; later errors are handled as usual. This captures the pattern of failing
+// fast on a misconfiguration at startup while tolerating transient failures afterwards.
+// It cannot be combined with WithGracePeriod, which tolerates errors at startup instead.
+func WithFailFastFirst(v bool) Option {
+	return failFastFirst(v)
+}
+
+type failFastFirst bool
+
+func (o failFastFirst) apply(c *config) {
+	c.FailFastFirst = bool(o)
+}
+
+// WithShutdownTimeout returns an Option to bound how long the ticker waits for the
+// executions in flight once a stop is requested, so that a task that never returns
+// cannot hang the shutdown.
+//
+// When the context is done or Handle.Stop is called, Run waits at most d for the
+// execution in progress, including the final one of WithFinalTick, and Handle.Drain
+// waits at most d for the executions of WithAsync once the ticker has finished. If d
+// is exceeded, they return ErrShutdownTimeout, joined with the context error if any, and
+// abandon the executions, whose goroutines keep running. Such a leak should be treated
+// as a bug in the task, which should observe its context. With d set, each execution
+// runs in its own goroutine so that it can be abandoned.
+//
+// A d of zero, the default, waits for the executions as long as they take. A negative
+// d is reported as an error wrapping ErrInvalidArgument.
+func WithShutdownTimeout(d time.Duration) Option {
+	return shutdownTimeout(d)
+}
+
+type shutdownTimeout time.Duration
+
+func (o shutdownTimeout) apply(c *config) {
+	c.Shutdown = time.Duration(o)
+}
+
+// WithLockOSThread returns an Option to set whether the ticker runs on a dedicated OS
+// thread, for tasks that must always run on the same thread, such as calls into C
+// libraries that keep thread-local state.
+//
+// When enabled, the goroutine of the ticker calls runtime.LockOSThread for the whole
+// run, so that every execution, along with the callbacks such as WithOnStart, runs on
+// the same thread, which no other goroutine uses meanwhile. The thread is held even
+// while the ticker waits for a tick, and waking the ticker costs a thread switch, so
+// this is only worth it when required. It applies to the executions that run on the
+// goroutine of the ticker, and is reported as ErrConflictingOptions together with
+// WithAsync, WithSkipIfRunning or WithShutdownTimeout, which run the executions on other
+// goroutines.
+func WithLockOSThread(v bool) Option {
+	return lockOSThread(v)
+}
+
+type lockOSThread bool
+
+func (o lockOSThread) apply(c *config) {
+	c.LockOSThread = bool(o)
+}
+
+// WithGracePeriod returns an Option to ignore the errors of the executions that start
+// within d after the ticker starts.
+//
+// It tolerates dependencies that are not ready yet when a process starts, such as a
+// database that is still coming up. An error within the period does not stop the ticker
+// and is not passed to WithOnError or WithDecider, but it is still logged, observed and
+// counted in Stats, and it still counts as a consecutive error for WithBackoff,
+// WithMaxConsecutiveErrors and WithHealth. It does not count for WithMaxErrors. After the
+// period, errors are handled as usual. The error returned by the handler of WithRecover
+// still stops the ticker.
+//
+// It is the opposite of WithFailFastFirst; combining both is reported as
+// ErrConflictingOptions. A negative d is reported as an error wrapping
+// ErrInvalidArgument.
+func WithGracePeriod(d time.Duration) Option {
+	return gracePeriod(d)
+}
+
+type gracePeriod time.Duration
+
+func (o gracePeriod) apply(c *config) {
+	c.GracePeriod = time.Duration(o)
+}
+
+// WithDeadlineError returns an Option to replace the error returned when the ticker
+// stops because the deadline of the context is exceeded.
+//
+// Instead of context.DeadlineExceeded, Run returns err. A nil err means that reaching
+// the deadline is a clean completion, and Run returns nil. To keep errors.Is matching
+// context.DeadlineExceeded, wrap it, e.g. fmt.Errorf("%w: batch window closed",
+// context.DeadlineExceeded). Cancellation of the context is not affected.
+func WithDeadlineError(err error) Option {
+	return &deadlineError{err: err}
+}
+
+type deadlineError struct {
+	err error
+}
+
+func (o *deadlineError) apply(c *config) {
+	c.DeadlineError = o
+}
+
+// WithContextErrorAsNil returns an Option to set whether the end of the context is a
+// clean stop.
+//
+// When enabled, Run returns nil instead of the context error, such as context.Canceled
+// or context.DeadlineExceeded, when the ticker stops because the context is done. It
+// takes precedence over WithDeadlineError. An error of the task, even one returned as
+// the context ends, is still returned, without the context error joined to it.
+func WithContextErrorAsNil(v bool) Option {
+	return contextErrorAsNil(v)
+}
+
+type contextErrorAsNil bool
+
+func (o contextErrorAsNil) apply(c *config) {
+	c.CtxErrAsNil = bool(o)
+}
+
+// WithPerTickContext returns an Option to derive a fresh context for each invocation
+// of the task.
+//
+// fn receives the context of the ticker and returns the context passed to the task,
+// e.g. with a trace span and a deadline attached, and a cancel function. The cancel
+// function, if not nil, is always called after the invocation returns, even if the task
+// panics. The timeout set by WithTimeout, if any, is applied on top of the derived context.
+func WithPerTickContext(fn func(parent context.Context) (context.Context, context.CancelFunc)) Option {
+	return perTickContext(fn)
+}
+
+type perTickContext func(context.Context) (context.Context, context.CancelFunc)
+
+func (o perTickContext) apply(c *config) {
+	c.PerTickContext = o
+}
+
+// WithContextWrapper returns an Option to wrap each invocation of the task, for example
+// to start a tracing span such as an OpenTelemetry one without this package depending on
+// a tracing library.
+//
+// fn receives the context of the ticker and the 1-based execution counter, and returns
+// the context passed to the task and a finisher. The finisher, if not nil, is called with
+// the error of the invocation once it returns, for example to record the status and end
+// the span. A panic recovered by WithRecover or WithPanicAsError is reported to the
+// finisher like an error. With WithRetry, each attempt is wrapped separately. The context
+// of WithPerTickContext and the timeout of WithTimeout are applied on top of the wrapped
+// context.
+func WithContextWrapper(fn func(ctx context.Context, n int) (context.Context, func(err error))) Option {
+	return contextWrapper(fn)
+}
+
+type contextWrapper func(context.Context, int) (context.Context, func(error))
+
+func (o contextWrapper) apply(c *config) {
+	c.ContextWrapper = o
+}
+
+// RateLimiter is the interface of a limiter shared across tickers, such as
+// *rate.Limiter of golang.org/x/time/rate.
+type RateLimiter interface {
+	// Wait blocks until an execution is allowed or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter returns an Option to pass each execution through a rate limiter.
+//
+// Wait is called before each execution, including the immediate one and those caught
+// up by WithCatchUp, so that the local schedule also respects a global budget shared
+// across many tickers. Ticks skipped by WithGate or while paused do not call Wait: the
+// gate is evaluated first. If Wait returns an error, the ticker stops and returns it.
+func WithRateLimiter(l RateLimiter) Option {
+	return rateLimiter{l}
+}
+
+type rateLimiter struct{ RateLimiter }
+
+func (o rateLimiter) apply(c *config) {
+	c.RateLimiter = o.RateLimiter
+}
+
+// WithMaxErrors returns an Option to stop the ticker once n executions have failed in
+// total, even if the errors are otherwise tolerated by WithStopOnError(false) or
+// WithOnError. Successful executions do not reset the count. The ticker returns the
+// most recent error.
+// A non-positive value means no limit.
+func WithMaxErrors(n int) Option {
+	return maxErrors(n)
+}
+
+type maxErrors int
+
+func (o maxErrors) apply(c *config) {
+	c.MaxErrors = int(o)
+}
+
+// WithMaxConsecutiveErrors returns an Option to stop the ticker once n executions in a
+// row have failed, like WithMaxErrors, except that a successful execution resets the
+// count.
+// A non-positive value means no limit.
+func WithMaxConsecutiveErrors(n int) Option {
+	return maxConsecutiveErrors(n)
+}
+
+type maxConsecutiveErrors int
+
+func (o maxConsecutiveErrors) apply(c *config) {
+	c.MaxConsErrors = int(o)
+}
+
+// WithFinalTick returns an Option to execute the task one last time when the context
+// is canceled, for example to flush buffered data on shutdown.
+//
+// The final execution receives a context that is not canceled with the parent, but
+// bounded by the timeout of WithTimeout or, if none, by the interval, if positive. It
+// runs after the execution in flight, if any, and regardless of WithGate or Pause. Its
+// error, unless ignored by WithOnError or WithStopOnError, is joined with the context
+// error. Stopping by other means, such as the limit or Handle.Stop, does not trigger it,
+// nor does a context that is already done when Run is called.
+func WithFinalTick(enabled bool) Option {
+	return finalTick(enabled)
+}
+
+type finalTick bool
+
+func (o finalTick) apply(c *config) {
+	c.FinalTick = bool(o)
+}
+
+// WithErrorChannel returns an Option to send each error of the task on ch, for example
+// to aggregate failures in another goroutine while WithStopOnError(false) ignores them.
+//
+// The send never blocks: if ch is full or not ready to receive, the error is dropped.
+// Use a buffered channel sized for the expected burst of errors. The ticker never
+// closes ch, which remains owned by the caller.
+func WithErrorChannel(ch chan<- error) Option {
+	return errorChannel{ch}
+}
+
+type errorChannel struct{ ch chan<- error }
+
+func (o errorChannel) apply(c *config) {
+	c.ErrorChan = o.ch
+}
+
+// WithReturnLastError returns an Option to return the error of the last execution when
+// the ticker stops cleanly, for example in the tolerant mode of WithStopOnError(false).
+//
+// When enabled, if the last execution failed, Run returns its error instead of nil when
+// the ticker reaches the limit or stops otherwise without an error. If the ticker stops
+// because the context is done, the error of the last execution is joined with the
+// context error, the former first, as for an execution error that stops the ticker. If
+// the last execution succeeded, the result of Run is unchanged.
+func WithReturnLastError(enabled bool) Option {
+	return returnLastError(enabled)
+}
+
+type returnLastError bool
+
+func (o returnLastError) apply(c *config) {
+	c.ReturnLast = bool(o)
+}
+
+// WithTrigger returns an Option to request early executions of the task through ch, while
+// still executing it at most once per interval, for example to run on change.
+//
+// A receive from ch executes the task right away if at least an interval has passed
+// since the last execution, and the regular schedule then restarts from it: the next
+// tick is due an interval later. Otherwise, the trigger is coalesced into the pending
+// tick, so rapid triggers result in a single execution. Since a regular tick is due at
+// most an interval after the previous one, a trigger brings an execution forward when
+// the schedule leaves a longer gap, such as before the first tick, with WithFirstInterval,
+// WithAlign or RunCron, or after ticks skipped by WithGate or Pause. Triggers are subject
+// to WithGate, Pause and WithLimit like ticks.
+//
+// The ticker never closes ch, and stops watching it once it is closed.
+func WithTrigger(ch <-chan struct{}) Option {
+	return trigger(ch)
+}
+
+type trigger <-chan struct{}
+
+func (o trigger) apply(c *config) {
+	c.Trigger = o
+}
+
+// WithLagObserver returns an Option to observe how late each tick fires relative to the
+// intended schedule, for example to detect that the task cannot keep up with the interval.
+//
+// fn is called on each tick, before the task is executed, with the time the tick was
+// scheduled for, the time it actually fired, and the lag between them. The scheduled time
+// follows the intended schedule: after a slow execution, the next tick reports the full
+// delay, even though the ticks missed meanwhile are dropped. A tick requested by
+// WithTrigger has no lag. Ticks skipped by WithGate, Pause or WithSkipIfRunning are also
+// observed.
+func WithLagObserver(fn func(scheduled, actual time.Time, lag time.Duration)) Option {
+	return lagObserver(fn)
+}
+
+type lagObserver func(scheduled, actual time.Time, lag time.Duration)
+
+func (o lagObserver) apply(c *config) {
+	c.LagObserver = o
+}
+
+// WithSaturationHandler returns an Option to be notified when the schedule cannot be met,
+// for example because the interval is shorter than the task takes.
+//
+// fn is called after each execution that leaves the following tick already due, with how
+// late that tick is. The ticker then fires it right away and, unless WithCatchUp is set,
+// drops the ticks it has missed beyond that one, which are counted in Stats.Dropped.
+// A handler that is called on every execution shows that the ticker effectively runs as
+// fast as the task.
+func WithSaturationHandler(fn func(lag time.Duration)) Option {
+	return saturationHandler(fn)
+}
+
+type saturationHandler func(time.Duration)
+
+func (o saturationHandler) apply(c *config) {
+	c.Saturation = o
+}
+
+// WithSemaphore returns an Option to bound the number of executions running at the same
+// time across all the tickers sharing sem, for example to protect a connection pool.
+//
+// The capacity of sem is the maximum number of concurrent executions. Each execution
+// acquires sem by sending to it before the task is invoked, including retries, and
+// releases it by receiving from it afterwards. An execution waits for sem as long as
+// needed, unless the context is done, in which case the ticker stops. The wait counts
+// as part of the execution: like during a slow execution, the ticks missed meanwhile
+// are dropped and the next one fires right away, unless WithCatchUp is set.
+func WithSemaphore(sem chan struct{}) Option {
+	return semaphore(sem)
+}
+
+type semaphore chan struct{}
+
+func (o semaphore) apply(c *config) {
+	c.Semaphore = o
+}
@@ -0,0 +1,60 @@
+package ticker
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrPanic indicates that the task panicked. It is wrapped by *PanicError, so
+// errors.Is(err, ErrPanic) reports whether err comes from a panic.
+var ErrPanic = errors.New("ticker: panic")
+
+// PanicError is the error returned for a panic in the task, if enabled by
+// WithPanicAsError.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("ticker: recovered panic: %v", e.Value)
+}
+
+// Unwrap returns ErrPanic and, if the panic value is an error, that error.
+func (e *PanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrPanic, err}
+	}
+	return []error{ErrPanic}
+}
+
+// Stack returns the stack trace of the goroutine at the time of the panic, as
+// formatted by runtime/debug.Stack.
+func (e *PanicError) Stack() []byte {
+	return e.stack
+}
+
+// WithPanicAsError returns an Option to turn a panic in the task into an error.
+//
+// When enabled, a panic is recovered and reported as a *PanicError, which wraps ErrPanic
+// and captures the stack. The error goes through the same path as an error returned by
+// the task, so it stops the ticker unless WithOnError or WithStopOnError says otherwise.
+// WithRecover, if set, takes precedence over this option.
+func WithPanicAsError(enabled bool) Option {
+	return panicAsError(enabled)
+}
+
+type panicAsError bool
+
+func (o panicAsError) apply(c *config) {
+	c.PanicAsError = bool(o)
+}
+
+// newPanicError returns a *PanicError for the panic value v, capturing the stack.
+func newPanicError(v any) *PanicError {
+	return &PanicError{Value: v, stack: debug.Stack()}
+}
@@ -0,0 +1,74 @@
+package ticker_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestWithPanicAsError tests that WithPanicAsError turns a panic into an error
+func TestWithPanicAsError(t *testing.T) {
+	t.Run("stops", func(t *testing.T) {
+		task := ticker.New(func() error { panic("boom") })
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithPanicAsError(true))
+		if !errors.Is(err, ticker.ErrPanic) {
+			t.Fatalf("expected error %v, got %v", ticker.ErrPanic, err)
+		}
+		if want := "ticker: recovered panic: boom"; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+		var pe *ticker.PanicError
+		if !errors.As(err, &pe) {
+			t.Fatalf("expected *PanicError, got %T", err)
+		}
+		if pe.Value != "boom" {
+			t.Errorf("expected panic value boom, got %v", pe.Value)
+		}
+		if !bytes.Contains(pe.Stack(), []byte("TestWithPanicAsError")) {
+			t.Errorf("expected the stack of the panic, got %s", pe.Stack())
+		}
+	})
+
+	t.Run("error value", func(t *testing.T) {
+		task := ticker.New(func() error { panic(io.EOF) })
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithPanicAsError(true))
+		if !errors.Is(err, ticker.ErrPanic) || !errors.Is(err, io.EOF) {
+			t.Errorf("expected both %v and %v, got %v", ticker.ErrPanic, io.EOF, err)
+		}
+	})
+
+	t.Run("normal error path", func(t *testing.T) {
+		var errs []error
+		task := ticker.New(func() error { panic("boom") })
+		err := task.Run(context.Background(), 10*time.Millisecond,
+			ticker.WithLimit(2),
+			ticker.WithPanicAsError(true),
+			ticker.WithOnError(func(err error) error {
+				errs = append(errs, err)
+				return nil
+			}),
+		)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if len(errs) != 2 || !errors.Is(errs[0], ticker.ErrPanic) {
+			t.Errorf("expected 2 panic errors passed to OnError, got %v", errs)
+		}
+	})
+
+	t.Run("recover takes precedence", func(t *testing.T) {
+		task := ticker.New(func() error { panic("boom") })
+		err := task.Run(context.Background(), 10*time.Millisecond,
+			ticker.WithPanicAsError(true),
+			ticker.WithRecover(func(any) error { return io.ErrUnexpectedEOF }),
+		)
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("expected error %v, got %v", io.ErrUnexpectedEOF, err)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package ticker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// registry holds the running tickers started by StartNamed, by name.
+var registry = struct {
+	sync.Mutex
+	m map[string]*Handle
+}{m: make(map[string]*Handle)}
+
+// StartNamed starts the task like Start, unless a ticker with the same name started by
+// StartNamed is still running, in which case it returns the Handle of that ticker and
+// starts nothing. It guards against scheduling the same job twice.
+//
+// The name is also applied with WithName, after options. A ticker is registered until
+// it finishes, for whatever reason, so the registry only retains the running tickers;
+// a ticker that never stops stays registered. If the arguments are invalid, nothing is
+// registered and Wait on the returned Handle returns the error.
+func StartNamed(ctx context.Context, name string, task Task, d time.Duration, options ...Option) *Handle {
+	registry.Lock()
+	defer registry.Unlock()
+	if h, ok := registry.m[name]; ok {
+		return h
+	}
+	h := task.Start(ctx, d, append(options[:len(options):len(options)], WithName(name))...)
+	r := h.current()
+	if r.s == nil {
+		return h
+	}
+	registry.m[name] = h
+	go func() {
+		<-r.done
+		registry.Lock()
+		defer registry.Unlock()
+		if registry.m[name] == h {
+			delete(registry.m, name)
+		}
+	}()
+	return h
+}
+
+// StopNamed stops the ticker started by StartNamed with the given name, like Handle.Stop,
+// and reports whether such a ticker was running.
+//
+// It does not wait for the ticker to finish, so IsRunning may still report true for a
+// short while.
+func StopNamed(name string) bool {
+	registry.Lock()
+	h, ok := registry.m[name]
+	registry.Unlock()
+	if ok {
+		h.Stop()
+	}
+	return ok
+}
+
+// IsRunning reports whether a ticker started by StartNamed with the given name is running.
+func IsRunning(name string) bool {
+	registry.Lock()
+	defer registry.Unlock()
+	_, ok := registry.m[name]
+	return ok
+}
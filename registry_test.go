@@ -0,0 +1,58 @@
+package ticker_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestStartNamed tests that StartNamed does not start a ticker twice
+func TestStartNamed(t *testing.T) {
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+	const name = "TestStartNamed"
+
+	h1 := ticker.StartNamed(context.Background(), name, task, time.Hour, ticker.WithImmediate(true))
+	h2 := ticker.StartNamed(context.Background(), name, task, time.Hour, ticker.WithImmediate(true))
+	if h1 != h2 {
+		t.Error("expected the Handle of the running ticker")
+	}
+	if !ticker.IsRunning(name) {
+		t.Error("expected the ticker to be running")
+	}
+
+	if !ticker.StopNamed(name) {
+		t.Error("expected StopNamed to find the ticker")
+	}
+	if err := h1.Wait(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n := count.Load(); n != 1 {
+		t.Errorf("expected 1 execution, got %d", n)
+	}
+
+	// The registration is removed shortly after the ticker finishes.
+	for i := 0; ticker.IsRunning(name); i++ {
+		if i == 100 {
+			t.Fatal("expected the ticker to be unregistered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if ticker.StopNamed(name) {
+		t.Error("expected StopNamed to find no ticker")
+	}
+
+	h3 := ticker.StartNamed(context.Background(), name, task, time.Hour, ticker.WithLimit(0))
+	if h3 == h1 {
+		t.Error("expected a new ticker once the previous one finished")
+	}
+	if err := h3.Wait(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package ticker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunFunc executes fn periodically like Task.Run, for a function that produces a value.
+//
+// Each value returned by a successful execution is sent on the channel set by
+// WithResults, if any, which turns the ticker into a periodic producer, for example to
+// poll an API and emit the parsed responses. Errors follow the same rules as for Run,
+// and the value returned along with an error is discarded.
+//
+// RunFunc returns ErrNilFunction if fn is nil, and an error wrapping ErrInvalidArgument
+// if the channel of WithResults or the condition of WithContinueIf is not for values of
+// type T.
+func RunFunc[T any](ctx context.Context, d time.Duration, fn func() (T, error), options ...Option) error {
+	var s *session
+	var results chan<- T
+	var continueIf func(T, error) bool
+	var task Task
+	if fn != nil {
+		task = func(ctx context.Context) error {
+			v, err := fn()
+			if continueIf != nil && !continueIf(v, err) {
+				s.halt()
+			}
+			if err != nil || results == nil {
+				return err
+			}
+			select {
+			case results <- v:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	s, err := newSession(task, d, options)
+	if err != nil {
+		return err
+	}
+	if s.c.Results != nil {
+		ch, ok := s.c.Results.(chan<- T)
+		if !ok {
+			return fmt.Errorf("%w: results channel of type %T does not match the function", ErrInvalidArgument, s.c.Results)
+		}
+		results = ch
+	}
+	if s.c.ContinueIf != nil {
+		f, ok := s.c.ContinueIf.(func(T, error) bool)
+		if !ok {
+			return fmt.Errorf("%w: condition of type %T does not match the function", ErrInvalidArgument, s.c.ContinueIf)
+		}
+		continueIf = f
+	}
+	return s.run(ctx)
+}
+
+// WithResults returns an Option to send the value of each successful execution of
+// RunFunc on ch.
+//
+// Unlike WithErrorChannel, the send blocks until ch is ready to receive or the context
+// is canceled, so a slow consumer holds back the ticker like a slow task. The ticker
+// never closes ch, which remains owned by the caller. WithResults has no effect on the
+// other ways to run a task, such as Task.Run.
+func WithResults[T any](ch chan<- T) Option {
+	if ch == nil {
+		return resultsOption{}
+	}
+	return resultsOption{ch}
+}
+
+type resultsOption struct{ ch any }
+
+func (o resultsOption) apply(c *config) {
+	c.Results = o.ch
+}
+
+// WithContinueIf returns an Option to decide after each execution of RunFunc whether
+// the ticker continues, from the value and the error returned by the function.
+//
+// fn is called right after each execution, before the result is sent to WithResults and
+// before waiting for the next tick. If it returns false, the ticker stops cleanly: Run
+// returns nil, unless the error of the execution stops the ticker by the usual rules, in
+// which case that error is returned. It expresses conditions such as "keep polling while
+// the status is pending". WithContinueIf has no effect on the other ways to run a task.
+func WithContinueIf[T any](fn func(v T, err error) bool) Option {
+	if fn == nil {
+		return continueIfOption{}
+	}
+	return continueIfOption{fn}
+}
+
+type continueIfOption struct{ fn any }
+
+func (o continueIfOption) apply(c *config) {
+	c.ContinueIf = o.fn
+}
@@ -0,0 +1,120 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+func ExampleRunFunc() {
+	results := make(chan int, 3)
+	n := 0
+	fn := func() (int, error) {
+		n++
+		return n * n, nil
+	}
+	err := ticker.RunFunc(context.Background(), 10*time.Millisecond, fn,
+		ticker.WithLimit(3),
+		ticker.WithResults(results),
+	)
+	close(results)
+	for v := range results {
+		fmt.Println(v)
+	}
+	fmt.Println(err)
+	// Output:
+	// 1
+	// 4
+	// 9
+	// <nil>
+}
+
+// TestRunFunc tests that RunFunc streams the successful results only
+func TestRunFunc(t *testing.T) {
+	errOdd := errors.New("odd")
+	results := make(chan int, 10)
+	n := 0
+	fn := func() (int, error) {
+		n++
+		if n%2 == 1 {
+			return -1, errOdd
+		}
+		return n, nil
+	}
+	err := ticker.RunFunc(context.Background(), 5*time.Millisecond, fn,
+		ticker.WithLimit(4),
+		ticker.WithStopOnError(false),
+		ticker.WithResults(results),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(results)
+	var got []int
+	for v := range results {
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[2 4]" {
+		t.Errorf("expected results [2 4], got %v", got)
+	}
+
+	err = ticker.RunFunc(context.Background(), 5*time.Millisecond, fn,
+		ticker.WithResults(make(chan string)),
+	)
+	if !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument for a mismatched channel, got %v", err)
+	}
+
+	err = ticker.RunFunc[int](context.Background(), 5*time.Millisecond, nil)
+	if !errors.Is(err, ticker.ErrNilFunction) {
+		t.Errorf("expected ErrNilFunction, got %v", err)
+	}
+}
+
+// TestWithResults_Cancel tests that a blocked send of a result returns on cancel
+func TestWithResults_Cancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	fn := func() (string, error) { return "value", nil }
+	err := ticker.RunFunc(ctx, 5*time.Millisecond, fn, ticker.WithResults(make(chan string)))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestWithContinueIf tests that WithContinueIf stops the ticker on the first false
+func TestWithContinueIf(t *testing.T) {
+	statuses := []string{"PENDING", "PENDING", "DONE", "PENDING"}
+	n := 0
+	fn := func() (string, error) {
+		n++
+		return statuses[n-1], nil
+	}
+	results := make(chan string, len(statuses))
+	err := ticker.RunFunc(context.Background(), 5*time.Millisecond, fn,
+		ticker.WithResults(results),
+		ticker.WithContinueIf(func(status string, err error) bool {
+			return err == nil && status == "PENDING"
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 executions, got %d", n)
+	}
+	if len(results) != 3 {
+		t.Errorf("expected the result of the last execution to be sent, got %d results", len(results))
+	}
+
+	err = ticker.RunFunc(context.Background(), 5*time.Millisecond, fn,
+		ticker.WithContinueIf(func(int, error) bool { return true }),
+	)
+	if !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument for a mismatched condition, got %v", err)
+	}
+}
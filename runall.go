@@ -0,0 +1,60 @@
+package ticker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Spec describes a ticker to be run by RunAll.
+type Spec struct {
+	// Task is the task to execute.
+	Task Task
+
+	// Interval is the interval between executions, as for Run.
+	Interval time.Duration
+
+	// Options are the options of the ticker.
+	Options []Option
+}
+
+// RunAll runs the tickers described by specs concurrently, each like Run, and waits for
+// all of them to finish.
+//
+// The tickers share a context derived from ctx. When a ticker returns a non-nil error,
+// the shared context is canceled so that the other tickers stop, and RunAll returns that
+// first error, like an errgroup. The arguments of all the specs are validated before any
+// ticker starts; if one is invalid, RunAll returns its error without running anything.
+func RunAll(ctx context.Context, specs ...Spec) error {
+	sessions := make([]*session, len(specs))
+	for i, spec := range specs {
+		s, err := newSession(spec.Task, spec.Interval, spec.Options)
+		if err != nil {
+			return err
+		}
+		sessions[i] = s
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for _, s := range sessions {
+		wg.Add(1)
+		go func(s *session) {
+			defer wg.Done()
+			if err := s.run(ctx); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}(s)
+	}
+	wg.Wait()
+	return first
+}
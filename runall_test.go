@@ -0,0 +1,80 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestRunAll tests that RunAll stops all the tickers on the first error
+func TestRunAll(t *testing.T) {
+	ErrTask := errors.New("task error")
+	var fast, slow atomic.Int32
+	err := ticker.RunAll(context.Background(),
+		ticker.Spec{
+			Task: ticker.New(func() error {
+				if fast.Add(1) == 3 {
+					return ErrTask
+				}
+				return nil
+			}),
+			Interval: 10 * time.Millisecond,
+		},
+		ticker.Spec{
+			Task: ticker.New(func() error {
+				slow.Add(1)
+				return nil
+			}),
+			Interval: time.Hour,
+			Options:  []ticker.Option{ticker.WithImmediate(true)},
+		},
+	)
+	if err != ErrTask {
+		t.Errorf("expected error %v, got %v", ErrTask, err)
+	}
+	if fast.Load() != 3 || slow.Load() != 1 {
+		t.Errorf("expected 3 and 1 executions, got %d and %d", fast.Load(), slow.Load())
+	}
+}
+
+// TestRunAll_Limit tests that RunAll returns nil once all the tickers are done
+func TestRunAll_Limit(t *testing.T) {
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+	err := ticker.RunAll(context.Background(),
+		ticker.Spec{Task: task, Interval: 10 * time.Millisecond, Options: []ticker.Option{ticker.WithLimit(2)}},
+		ticker.Spec{Task: task, Interval: 20 * time.Millisecond, Options: []ticker.Option{ticker.WithLimit(3)}},
+	)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count.Load() != 5 {
+		t.Errorf("expected 5 executions, got %d", count.Load())
+	}
+}
+
+// TestRunAll_Invalid tests that RunAll runs nothing if a spec is invalid
+func TestRunAll_Invalid(t *testing.T) {
+	var count atomic.Int32
+	task := ticker.New(func() error {
+		count.Add(1)
+		return nil
+	})
+	err := ticker.RunAll(context.Background(),
+		ticker.Spec{Task: task, Interval: time.Millisecond, Options: []ticker.Option{ticker.WithImmediate(true)}},
+		ticker.Spec{Task: task, Interval: 0},
+	)
+	if err != ticker.ErrNonPositiveInterval {
+		t.Errorf("expected error %v, got %v", ticker.ErrNonPositiveInterval, err)
+	}
+	if count.Load() != 0 {
+		t.Errorf("expected no execution, got %d", count.Load())
+	}
+}
@@ -0,0 +1,91 @@
+package ticker
+
+import (
+	"context"
+	"time"
+)
+
+// Config describes the behavior of a ticker as data, for example unmarshaled from a
+// configuration file. Each field corresponds to an option; the zero value of a field
+// leaves the default behavior of Run.
+//
+// Durations are time.Duration values, which encoding/json represents as integers in
+// nanoseconds.
+type Config struct {
+	// Interval is the interval between executions, as given to Run.
+	Interval time.Duration `json:"interval"`
+
+	// Immediate corresponds to WithImmediate.
+	Immediate bool `json:"immediate,omitempty"`
+
+	// Limit corresponds to WithLimit, except that 0 means no limit.
+	Limit int `json:"limit,omitempty"`
+
+	// MaxDuration corresponds to WithMaxDuration.
+	MaxDuration time.Duration `json:"maxDuration,omitempty"`
+
+	// Timeout corresponds to WithTimeout.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// StopOnError corresponds to WithStopOnError. If nil, task errors stop the ticker.
+	StopOnError *bool `json:"stopOnError,omitempty"`
+
+	// Jitter corresponds to WithJitter.
+	Jitter float64 `json:"jitter,omitempty"`
+
+	// Align corresponds to WithAlign.
+	Align bool `json:"align,omitempty"`
+
+	// SkipIfRunning corresponds to WithSkipIfRunning.
+	SkipIfRunning bool `json:"skipIfRunning,omitempty"`
+
+	// FixedDelay corresponds to WithFixedDelay.
+	FixedDelay bool `json:"fixedDelay,omitempty"`
+
+	// Name corresponds to WithName.
+	Name string `json:"name,omitempty"`
+}
+
+// RunConfig executes the task like Run, with the interval and options described by cfg.
+//
+// The fields of cfg are translated into the corresponding options, so RunConfig returns
+// the same errors as Run for invalid values.
+func (task Task) RunConfig(ctx context.Context, cfg Config) error {
+	return task.Run(ctx, cfg.Interval, cfg.options()...)
+}
+
+// options returns the options described by cfg.
+func (cfg Config) options() []Option {
+	var options []Option
+	if cfg.Immediate {
+		options = append(options, WithImmediate(true))
+	}
+	if cfg.Limit != 0 {
+		options = append(options, WithLimit(cfg.Limit))
+	}
+	if cfg.MaxDuration != 0 {
+		options = append(options, WithMaxDuration(cfg.MaxDuration))
+	}
+	if cfg.Timeout != 0 {
+		options = append(options, WithTimeout(cfg.Timeout))
+	}
+	if cfg.StopOnError != nil {
+		options = append(options, WithStopOnError(*cfg.StopOnError))
+	}
+	if cfg.Jitter != 0 {
+		options = append(options, WithJitter(cfg.Jitter))
+	}
+	if cfg.Align {
+		options = append(options, WithAlign(true))
+	}
+	if cfg.SkipIfRunning {
+		options = append(options, WithSkipIfRunning(true))
+	}
+	if cfg.FixedDelay {
+		options = append(options, WithFixedDelay(true))
+	}
+	if cfg.Name != "" {
+		options = append(options, WithName(cfg.Name))
+	}
+	return options
+}
@@ -0,0 +1,46 @@
+package ticker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestRunConfig tests that RunConfig applies a Config unmarshaled from JSON
+func TestRunConfig(t *testing.T) {
+	var cfg ticker.Config
+	data := `{"interval": 10000000, "immediate": true, "limit": 3, "stopOnError": false, "name": "job"}`
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return errors.New("ignored")
+	})
+	if err := task.RunConfig(context.Background(), cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 executions, got %d", count)
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected the immediate execution and 2 ticks, took %v", elapsed)
+	}
+
+	err := task.RunConfig(context.Background(), ticker.Config{Interval: time.Second, Limit: 1, Immediate: true, Name: "job"})
+	if err == nil || err.Error() != `ticker "job": ignored` {
+		t.Errorf("expected the error to stop the ticker by default, got %v", err)
+	}
+
+	err = task.RunConfig(context.Background(), ticker.Config{})
+	if err != ticker.ErrNonPositiveInterval {
+		t.Errorf("expected error %v, got %v", ticker.ErrNonPositiveInterval, err)
+	}
+}
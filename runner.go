@@ -0,0 +1,35 @@
+package ticker
+
+import (
+	"context"
+	"time"
+)
+
+// Runner runs tasks with a set of options configured once.
+//
+// It separates the policy, expressed as options, from the tasks, so that a standard
+// configuration can be shared across many tasks. A Runner is safe for concurrent use.
+type Runner struct {
+	options []Option
+}
+
+// Configure returns a new Runner that applies the given options to every run.
+func Configure(options ...Option) *Runner {
+	return &Runner{options: append([]Option(nil), options...)}
+}
+
+// Run executes the task like task.Run with the options of the Runner.
+//
+// Additional options are applied after the options of the Runner, so they take
+// precedence for options that hold a single value.
+func (r *Runner) Run(ctx context.Context, task Task, d time.Duration, options ...Option) error {
+	return task.Run(ctx, d, r.with(options)...)
+}
+
+// with returns the options of the Runner followed by options.
+func (r *Runner) with(options []Option) []Option {
+	if len(options) == 0 {
+		return r.options
+	}
+	return append(r.options[:len(r.options):len(r.options)], options...)
+}
@@ -0,0 +1,40 @@
+package ticker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestRunner tests that a Runner applies its options to every run
+func TestRunner(t *testing.T) {
+	runner := ticker.Configure(ticker.WithImmediate(true), ticker.WithLimit(2))
+
+	counts := make([]int, 2)
+	for i := range counts {
+		task := ticker.New(func() error {
+			counts[i]++
+			return nil
+		})
+		if err := runner.Run(context.Background(), task, 10*time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if counts[0] != 2 || counts[1] != 2 {
+		t.Errorf("expected 2 executions of each task, got %v", counts)
+	}
+
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+	if err := runner.Run(context.Background(), task, 10*time.Millisecond, ticker.WithLimit(3)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected the additional option to take precedence, got %d executions", count)
+	}
+}
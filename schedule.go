@@ -0,0 +1,211 @@
+package ticker
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"time"
+)
+
+// Schedule runs several tasks with their own intervals on a single goroutine.
+//
+// Unlike RunAll, which runs a ticker per task, a Schedule keeps the entries in a
+// min-heap by due time and waits for the earliest with a single timer, which is more
+// efficient for many tasks. The executions never overlap.
+type Schedule struct {
+	// Entries are the tasks to run, with their intervals and options.
+	Entries []Spec
+
+	// OnError, if not nil, is called with the index of an entry and the error that
+	// stopped it.
+	OnError func(i int, err error)
+
+	// StopOnError stops the whole schedule on the first error that stops an entry.
+	// By default, the other entries keep running.
+	StopOnError bool
+}
+
+// Run runs the entries of the schedule until the context is done, every entry has
+// stopped, or, with StopOnError, an entry fails.
+//
+// Each entry is executed when it is due. Entries due at the same time are executed in
+// the order of Entries. The options of an entry apply to it as for Run, including
+// WithLimit, WithOnError and WithStopOnError, which decide whether an error stops the
+// entry, WithContextValue, and WithName, which annotates the error of the entry. The
+// callbacks of WithOnStart, WithOnStop and WithObservers are called for each entry as
+// if it were a ticker of its own. An entry stops at the end set by WithMaxDuration or
+// WithEndTime, counted from the start of Run. When the schedule stops, the entries
+// still running stop with it, with the context error as adjusted by WithDeadlineError
+// and WithContextErrorAsNil if the context is done, and nil otherwise.
+//
+// Options that apply to the loop of a ticker rather than to the executions, such as
+// WithImmediate, WithSkipIfRunning, WithAsync, WithCatchUp, WithTrigger, WithStartDelay,
+// WithStopChan, WithStopSignal, WithFinalTick, WithShutdownTimeout, WithLockOSThread,
+// WithStatsInterval, WithOnLimitReached and WithReturnLastError, have no effect: like
+// time.Ticker, an entry that is late fires once and drops the ticks it missed. The
+// schedule follows the clock of the first entry.
+//
+// Run returns the context error if the context is done, the error that stopped an entry
+// with StopOnError, and nil otherwise; the options of the entries do not change it. The
+// arguments of all the entries are validated before anything runs; if one is invalid,
+// Run returns its error.
+func (sc *Schedule) Run(ctx context.Context) error {
+	sessions := make([]*session, len(sc.Entries))
+	for i, spec := range sc.Entries {
+		s, err := newSession(spec.Task, spec.Interval, spec.Options)
+		if err != nil {
+			return err
+		}
+		sessions[i] = s
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	clock := sessions[0].c.Clock
+	now := clock.Now()
+	q := make(scheduleQueue, 0, len(sessions))
+	for i, s := range sessions {
+		s.start = now
+		if s.c.OnStart != nil {
+			s.c.OnStart()
+		}
+		end, _ := s.deadline()
+		if s.exhausted() || !end.IsZero() && !end.After(now) {
+			s.finish(nil)
+			continue
+		}
+		e := &scheduleEntry{index: i, s: s, ctx: s.withValues(ctx), end: end}
+		e.schedule(s.first(now))
+		s.reuse(e.ctx)
+		q = append(q, e)
+	}
+	heap.Init(&q)
+	// The entries still running when the schedule stops are stopped with it.
+	defer func() {
+		for _, e := range q {
+			e.s.finish(e.s.contextErr(ctx))
+		}
+	}()
+
+	var t Timer
+	defer func() {
+		if t != nil {
+			t.Stop()
+		}
+	}()
+	for q.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e := q[0]
+		if d := e.next.Sub(clock.Now()); d > 0 {
+			if t == nil {
+				t = clock.NewTimer(d)
+			} else {
+				t.Reset(d)
+			}
+			select {
+			case <-t.C():
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		s := e.s
+		if e.ended() {
+			heap.Pop(&q)
+			s.finish(nil)
+			continue
+		}
+		if s.ready() {
+			if !s.take() {
+				heap.Pop(&q)
+				s.finish(nil)
+				continue
+			}
+			if err := s.exec(e.ctx); err != nil {
+				heap.Pop(&q)
+				if errors.Is(err, errDecided) {
+					// The decider of WithDecider stopped the entry cleanly.
+					s.finish(nil)
+					continue
+				}
+				err = s.finish(err)
+				if sc.OnError != nil {
+					sc.OnError(e.index, err)
+				}
+				if sc.StopOnError {
+					return err
+				}
+				continue
+			}
+		}
+		if s.exhausted() {
+			heap.Pop(&q)
+			s.finish(nil)
+			continue
+		}
+		prev := e.next
+		if s.c.FixedDelay {
+			prev = clock.Now()
+		}
+		next := s.after(prev)
+		if now := clock.Now(); next.Before(now) {
+			next = now
+		}
+		e.schedule(next)
+		heap.Fix(&q, 0)
+	}
+	return nil
+}
+
+// scheduleEntry is an entry of a Schedule with the time it is due.
+type scheduleEntry struct {
+	index int
+	s     *session
+	ctx   context.Context
+	next  time.Time
+
+	// end is the time the entry stops by WithMaxDuration or WithEndTime, or the zero
+	// time if there is none.
+	end time.Time
+}
+
+// schedule sets the entry due at next, or at its end if that comes first, so that it
+// stops on time.
+func (e *scheduleEntry) schedule(next time.Time) {
+	if !e.end.IsZero() && e.end.Before(next) {
+		next = e.end
+	}
+	e.next = next
+}
+
+// ended reports whether the entry is due at its end.
+func (e *scheduleEntry) ended() bool {
+	return !e.end.IsZero() && !e.next.Before(e.end)
+}
+
+// scheduleQueue is a min-heap of the entries of a Schedule by due time, then by index.
+type scheduleQueue []*scheduleEntry
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool {
+	if !q[i].next.Equal(q[j].next) {
+		return q[i].next.Before(q[j].next)
+	}
+	return q[i].index < q[j].index
+}
+
+func (q scheduleQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *scheduleQueue) Push(x any) { *q = append(*q, x.(*scheduleEntry)) }
+
+func (q *scheduleQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
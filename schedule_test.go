@@ -0,0 +1,223 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+	"github.com/goaux/ticker/clocktest"
+)
+
+// TestSchedule tests that a Schedule fires each entry when due, in order
+func TestSchedule(t *testing.T) {
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	var mu sync.Mutex
+	var fired []string
+	entry := func(name string, d time.Duration) ticker.Spec {
+		return ticker.Spec{
+			Task: ticker.New(func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				fired = append(fired, fmt.Sprintf("%s@%d", name, clock.Now().Second()))
+				return nil
+			}),
+			Interval: d,
+			Options:  []ticker.Option{ticker.WithClock(clock)},
+		}
+	}
+	sc := &ticker.Schedule{Entries: []ticker.Spec{
+		entry("c", 3*time.Second),
+		entry("b", 2*time.Second),
+		entry("a", time.Second),
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- sc.Run(ctx) }()
+	for i := 0; i < 6; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	want := "a@1 b@2 a@2 c@3 a@3 b@4 a@4 a@5 c@6 b@6 a@6"
+	if got := strings.Join(fired, " "); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+// TestSchedule_Errors tests that an error stops only its entry, unless StopOnError is set
+func TestSchedule_Errors(t *testing.T) {
+	errTask := errors.New("task error")
+	var count int
+	newSchedule := func() *ticker.Schedule {
+		count = 0
+		return &ticker.Schedule{Entries: []ticker.Spec{
+			{Task: ticker.New(func() error { return errTask }), Interval: time.Millisecond},
+			{Task: ticker.New(func() error { count++; return nil }), Interval: time.Millisecond, Options: []ticker.Option{ticker.WithLimit(5)}},
+		}}
+	}
+
+	sc := newSchedule()
+	var failed []int
+	sc.OnError = func(i int, err error) {
+		if errors.Is(err, errTask) {
+			failed = append(failed, i)
+		}
+	}
+	if err := sc.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(failed) != "[0]" || count != 5 {
+		t.Errorf("expected entry 0 to fail and entry 1 to run 5 times, got %v and %d", failed, count)
+	}
+
+	sc = newSchedule()
+	sc.StopOnError = true
+	if err := sc.Run(context.Background()); !errors.Is(err, errTask) {
+		t.Errorf("expected error %v, got %v", errTask, err)
+	}
+	if count > 1 {
+		t.Errorf("expected the schedule to stop on the first error, got %d executions", count)
+	}
+
+	sc = &ticker.Schedule{Entries: []ticker.Spec{{Task: nil, Interval: time.Second}}}
+	if err := sc.Run(context.Background()); !errors.Is(err, ticker.ErrNilFunction) {
+		t.Errorf("expected ErrNilFunction, got %v", err)
+	}
+}
+
+// TestSchedule_Decider tests that an entry stopped by WithDecider stops cleanly
+func TestSchedule_Decider(t *testing.T) {
+	count := 0
+	sc := &ticker.Schedule{
+		Entries: []ticker.Spec{{
+			Task:     ticker.New(func() error { count++; return nil }),
+			Interval: time.Millisecond,
+			Options: []ticker.Option{
+				ticker.WithDecider(func(d ticker.Decision) bool { return d.Index < 2 }),
+			},
+		}},
+		OnError:     func(i int, err error) { t.Errorf("unexpected error for entry %d: %v", i, err) },
+		StopOnError: true,
+	}
+	if err := sc.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 executions, got %d", count)
+	}
+}
+
+// TestSchedule_Options tests that a Schedule applies the per-run options of its entries
+func TestSchedule_Options(t *testing.T) {
+	type key struct{}
+	var events []string
+	errFail := errors.New("fail")
+	var got error
+	sc := &ticker.Schedule{
+		Entries: []ticker.Spec{{
+			Task: ticker.NewContext(func(ctx context.Context) error {
+				events = append(events, fmt.Sprint("value:", ctx.Value(key{})))
+				return errFail
+			}),
+			Interval: time.Millisecond,
+			Options: []ticker.Option{
+				ticker.WithContextValue(key{}, 42),
+				ticker.WithName("job"),
+				ticker.WithOnStart(func() { events = append(events, "start") }),
+				ticker.WithOnStop(func(err error) { events = append(events, fmt.Sprint("stop:", err)) }),
+			},
+		}},
+		OnError: func(i int, err error) { got = err },
+	}
+	if err := sc.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `[start value:42 stop:ticker "job": fail]`; fmt.Sprint(events) != want {
+		t.Errorf("expected %s, got %v", want, events)
+	}
+	if !errors.Is(got, errFail) || !strings.Contains(fmt.Sprint(got), `ticker "job"`) {
+		t.Errorf("expected the named error, got %v", got)
+	}
+
+	t.Run("context done", func(t *testing.T) {
+		var stops []string
+		stop := func(err error) { stops = append(stops, fmt.Sprint(err)) }
+		ctx, cancel := context.WithCancel(context.Background())
+		sc := &ticker.Schedule{Entries: []ticker.Spec{
+			{
+				Task:     ticker.New(func() error { cancel(); return nil }),
+				Interval: time.Millisecond,
+				Options:  []ticker.Option{ticker.WithOnStop(stop)},
+			},
+			{
+				Task:     ticker.New(func() error { return nil }),
+				Interval: time.Hour,
+				Options:  []ticker.Option{ticker.WithOnStop(stop), ticker.WithContextErrorAsNil(true)},
+			},
+		}}
+		if err := sc.Run(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if want := "[context canceled <nil>]"; fmt.Sprint(stops) != want {
+			t.Errorf("expected %s, got %v", want, stops)
+		}
+	})
+}
+
+// TestSchedule_EndTime tests that an entry stops at the end of WithEndTime or
+// WithMaxDuration
+func TestSchedule_EndTime(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(start)
+	var fired []string
+	var stops []string
+	entry := func(name string, options ...ticker.Option) ticker.Spec {
+		return ticker.Spec{
+			Task: ticker.New(func() error {
+				fired = append(fired, fmt.Sprintf("%s@%d", name, clock.Now().Sub(start)/time.Second))
+				return nil
+			}),
+			Interval: time.Second,
+			Options: append([]ticker.Option{
+				ticker.WithClock(clock),
+				ticker.WithLimit(4),
+				ticker.WithOnStop(func(err error) { stops = append(stops, fmt.Sprintf("%s:%v", name, err)) }),
+			}, options...),
+		}
+	}
+	sc := &ticker.Schedule{Entries: []ticker.Spec{
+		entry("past", ticker.WithEndTime(start.Add(-time.Second))),
+		entry("end", ticker.WithEndTime(start.Add(2500*time.Millisecond))),
+		entry("max", ticker.WithMaxDuration(1500*time.Millisecond)),
+		entry("limit"),
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- sc.Run(context.Background()) }()
+	for i := 0; i < 4; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "end@1 max@1 limit@1 end@2 limit@2 limit@3 limit@4"
+	if got := strings.Join(fired, " "); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if want := "[past:<nil> max:<nil> end:<nil> limit:<nil>]"; fmt.Sprint(stops) != want {
+		t.Errorf("expected %s, got %v", want, stops)
+	}
+}
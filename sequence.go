@@ -0,0 +1,94 @@
+package ticker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunSequence executes the task after each interval of a sequence, such as 1s, 2s and
+// 5s for a warmup, instead of a single interval.
+//
+// The ticker waits intervals[0] before the first tick, intervals[1] before the second,
+// and so on. Once the sequence is exhausted, the ticker stops and RunSequence returns
+// nil, or, with WithRepeatLast, keeps ticking at the last interval, so that 1s, 2s, 5s
+// and 10s with WithRepeatLast means "1s, 2s, 5s, then steady 10s". RunSequence returns
+// an error wrapping ErrInvalidArgument for an empty sequence, and
+// ErrNonPositiveInterval if an interval is not positive.
+//
+// Options apply as for Run. WithLimit bounds the executions independently of the
+// sequence: the ticker stops at whichever comes first, the limit or the end of the
+// sequence, and the immediate executions of WithImmediate and WithBurst count toward
+// the limit but do not consume the sequence. Like for RunCron, options that adjust the
+// interval, such as WithBackoff, WithJitter, WithInterval, WithAlign and
+// WithFixedDelay, have no effect. The ticks are placed at the sums of the intervals
+// since the start, and, like the ticks of Run, a tick missed while the task was running
+// fires once as soon as it completes.
+func (task Task) RunSequence(ctx context.Context, intervals []time.Duration, options ...Option) error {
+	if len(intervals) == 0 {
+		return fmt.Errorf("%w: empty interval sequence", ErrInvalidArgument)
+	}
+	for _, d := range intervals {
+		if d <= 0 {
+			return ErrNonPositiveInterval
+		}
+	}
+	s, err := newSession(task, intervals[0], options)
+	if err != nil {
+		return err
+	}
+	seq := &sequence{offsets: make([]time.Duration, len(intervals)), repeat: s.c.RepeatLast}
+	var sum time.Duration
+	for i, d := range intervals {
+		sum += d
+		seq.offsets[i] = sum
+	}
+	s.sched = seq.next
+	return s.run(ctx)
+}
+
+// WithRepeatLast returns an Option to set whether RunSequence keeps ticking at the last
+// interval of the sequence once it is exhausted, instead of stopping.
+func WithRepeatLast(v bool) Option {
+	return repeatLast(v)
+}
+
+type repeatLast bool
+
+func (o repeatLast) apply(c *config) {
+	c.RepeatLast = bool(o)
+}
+
+// sequence is the schedule of RunSequence.
+type sequence struct {
+	// origin is the time the schedule starts from, set on the first call to next.
+	origin time.Time
+
+	// offsets are the times of the ticks since origin, the running sums of the intervals.
+	offsets []time.Duration
+
+	// repeat repeats the last interval after the last tick.
+	repeat bool
+}
+
+// next returns the time of the first tick after t, or the zero time if there is none.
+func (q *sequence) next(t time.Time) time.Time {
+	if q.origin.IsZero() {
+		q.origin = t
+	}
+	for _, offset := range q.offsets {
+		if next := q.origin.Add(offset); next.After(t) {
+			return next
+		}
+	}
+	if !q.repeat {
+		return time.Time{}
+	}
+	n := len(q.offsets)
+	last := q.offsets[n-1]
+	if n > 1 {
+		last -= q.offsets[n-2]
+	}
+	end := q.origin.Add(q.offsets[n-1])
+	return end.Add((t.Sub(end)/last + 1) * last)
+}
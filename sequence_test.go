@@ -0,0 +1,92 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+	"github.com/goaux/ticker/clocktest"
+)
+
+// TestRunSequence tests that the ticks follow the sequence, and stop or repeat the last interval at its end
+func TestRunSequence(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options []ticker.Option
+		steps   int
+		want    string
+	}{
+		{"stop", nil, 6, "[1s 3s 6s]"},
+		{"repeat last", []ticker.Option{ticker.WithRepeatLast(true), ticker.WithLimit(5)}, 12, "[1s 3s 6s 9s 12s]"},
+		{"limit first", []ticker.Option{ticker.WithLimit(2)}, 3, "[1s 3s]"},
+		{"immediate", []ticker.Option{ticker.WithImmediate(true)}, 6, "[0s 1s 3s 6s]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+			clock := clocktest.NewClock(start)
+			var got []time.Duration
+			task := ticker.New(func() error {
+				got = append(got, clock.Now().Sub(start))
+				return nil
+			})
+			done := make(chan error, 1)
+			go func() {
+				done <- task.RunSequence(context.Background(),
+					[]time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+					append(tt.options, ticker.WithClock(clock))...,
+				)
+			}()
+			for i := 0; i < tt.steps; i++ {
+				clock.BlockUntil(1)
+				clock.Advance(time.Second)
+			}
+			if err := <-done; err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(got) != tt.want {
+				t.Errorf("expected executions at %s, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("late", func(t *testing.T) {
+		start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+		clock := clocktest.NewClock(start)
+		var got []time.Duration
+		task := ticker.New(func() error {
+			got = append(got, clock.Now().Sub(start))
+			if len(got) == 1 {
+				// The first execution outlasts the last tick of the sequence.
+				clock.Advance(50 * time.Second)
+			}
+			return nil
+		})
+		done := make(chan error, 1)
+		go func() {
+			done <- task.RunSequence(context.Background(),
+				[]time.Duration{10 * time.Second, 10 * time.Second},
+				ticker.WithClock(clock),
+			)
+		}()
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Second)
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != "[10s 1m0s]" {
+			t.Errorf("expected the missed tick to fire once late, got %v", got)
+		}
+	})
+
+	task := ticker.New(func() error { return nil })
+	if err := task.RunSequence(context.Background(), nil); !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+	err := task.RunSequence(context.Background(), []time.Duration{time.Second, 0})
+	if !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected ErrNonPositiveInterval, got %v", err)
+	}
+}
@@ -0,0 +1,1011 @@
+package ticker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// session holds the state of a single run of a Task.
+type session struct {
+	task Task
+	c    *config
+
+	// mu guards d, iv, next, limit and stats.
+	mu sync.Mutex
+
+	// d is the base interval, and iv is the current interval.
+	d, iv time.Duration
+
+	// next is the time of the pending tick, or the zero time if there is none.
+	next time.Time
+
+	// limit is the number of executions left, or negative if unlimited.
+	limit int
+
+	// halted is set by halt to stop the ticker before any further execution, when
+	// WithContinueIf stops it, when the loop of Ticks breaks, or when the schedule of
+	// RunSequence has no more ticks.
+	halted bool
+
+	// stats records the activity so far.
+	stats Stats
+
+	// spent is the total time spent executing the task, for WithTotalExecBudget.
+	spent time.Duration
+
+	// stop is closed to request a clean stop. It is nil for Run.
+	stop chan struct{}
+
+	// resetc notifies the loop that d and iv were changed by Handle.Reset.
+	// It is nil for Run.
+	resetc chan struct{}
+
+	// limitc notifies the loop that the limit was changed by Handle.SetLimit.
+	// It is nil for Run.
+	limitc chan struct{}
+
+	// sched returns the time of the tick following the given one, or the zero time if
+	// there is none, for RunCron and RunSequence. If nil, the ticks follow the interval.
+	sched func(time.Time) time.Time
+
+	// paused suppresses executions while set.
+	paused atomic.Bool
+
+	// unhealthy is set by WithHealth after too many consecutive failures.
+	unhealthy atomic.Bool
+
+	// failures and consecutive count the failed executions for WithMaxErrors and
+	// WithMaxConsecutiveErrors. They are only accessed by exec, which never runs
+	// concurrently with itself, except with WithAsync, where settle guards them.
+	failures, consecutive int
+
+	// settle serializes what follows the executions of WithAsync, which may run
+	// concurrently.
+	settle sync.Mutex
+
+	// wg tracks the executions in flight of WithAsync, for Handle.Drain.
+	wg sync.WaitGroup
+
+	// asyncErr carries the error of an execution of WithAsync that stops the ticker.
+	// It is nil without WithAsync.
+	asyncErr chan error
+
+	// last is the error of the last execution, and lastRun the time it completed.
+	// They are guarded by mu.
+	last    error
+	lastRun time.Time
+
+	// start is the time the run started.
+	start time.Time
+
+	// tctx is the context reused by the executions; see tickContext.
+	tctx *reusedContext
+
+	// until inverts the error semantics for Until: a successful execution stops the
+	// ticker and a failed one continues it. lastErr holds the last failure.
+	until   bool
+	lastErr error
+}
+
+// newSession validates the arguments and returns a session for the task.
+func newSession(task Task, d time.Duration, options []Option) (*session, error) {
+	c, err := newConfig(options)
+	if d < 0 || d == 0 && err == nil && !c.Spin {
+		return nil, ErrNonPositiveInterval
+	}
+
+	if task == nil {
+		return nil, ErrNilFunction
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if task = c.wrap(task); task == nil {
+		return nil, ErrNilFunction
+	}
+	return &session{task: task, d: d, c: c, iv: d, limit: c.Limit}, nil
+}
+
+// applyOptions applies the options to the default configuration.
+func applyOptions(options []Option) *config {
+	c := &config{
+		Limit:       -1,
+		StopOnError: true,
+		Clock:       realClock{},
+	}
+	for _, opt := range options {
+		opt.apply(c)
+	}
+	return c
+}
+
+// newConfig applies the options to the default configuration and validates it.
+func newConfig(options []Option) (*config, error) {
+	c := applyOptions(options)
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// run executes the task until the context is canceled, a stop is requested, the
+// maximum duration elapses, the end time passes or, if the limit is positive, the
+// execution limit is reached.
+// It respects the immediate execution option.
+func (s *session) run(ctx context.Context) (err error) {
+	if s.c.LockOSThread {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
+	if s.c.OnStart != nil {
+		s.c.OnStart()
+	}
+	defer func() { err = s.finish(err) }()
+	if s.until {
+		defer func() { err = s.untilResult(err) }()
+	}
+	if s.c.ReturnLast {
+		defer func() {
+			s.mu.Lock()
+			last := s.last
+			s.mu.Unlock()
+			if last != nil && !errors.Is(err, last) {
+				err = joinStop(last, err)
+			}
+		}()
+	}
+	s.start = s.c.Clock.Now()
+	if s.c.Decider != nil {
+		defer func() {
+			if errors.Is(err, errDecided) {
+				err = s.contextErr(ctx)
+			}
+		}()
+	}
+
+	if s.c.OnLimit != nil {
+		// Registered last so that it sees the error before it is translated.
+		defer func() {
+			s.mu.Lock()
+			reached := s.limit == 0
+			s.mu.Unlock()
+			if err != nil || !reached {
+				return
+			}
+			// The executions of WithAsync still in flight are planned ones as well.
+			s.wg.Wait()
+			s.mu.Lock()
+			total := s.stats.Executions
+			s.mu.Unlock()
+			s.c.OnLimit(total)
+		}()
+	}
+	if s.c.StatsReport != nil {
+		defer s.reportStats()()
+	}
+	ctx = s.withValues(ctx)
+	s.reuse(ctx)
+	if s.c.Async {
+		s.asyncErr = make(chan error, 1)
+	}
+
+	if s.exhausted() {
+		return nil
+	}
+	var end <-chan time.Time
+	if deadline, ok := s.deadline(); ok {
+		d := deadline.Sub(s.c.Clock.Now())
+		if d <= 0 {
+			return nil
+		}
+		t := s.c.Clock.NewTimer(d)
+		defer t.Stop()
+		end = t.C()
+	}
+	if s.c.StartDelay > 0 {
+		if ok, err := s.startDelay(ctx, end); !ok {
+			return err
+		}
+	}
+	// A context done before the start stops the ticker without any execution, not even
+	// the final one of WithFinalTick.
+	if ctx.Err() != nil {
+		return s.contextErr(ctx)
+	}
+	// A canceled context stops the burst of immediate executions.
+	for i := 0; i < s.c.Burst && ctx.Err() == nil; i++ {
+		if !s.ready() {
+			continue
+		}
+		if !s.take() {
+			return nil
+		}
+		if s.c.Async {
+			s.launch(ctx)
+			continue
+		}
+		if err := s.execBounded(ctx); err != nil {
+			return joinStop(err, s.contextErr(ctx))
+		}
+	}
+	if s.exhausted() {
+		return nil
+	}
+	if s.base() == 0 {
+		return s.spin(ctx, end)
+	}
+	clock := s.c.Clock
+	prev := clock.Now()
+	next := s.first(prev)
+	s.setNext(next)
+	defer s.setNext(time.Time{})
+	t := clock.NewTimer(next.Sub(prev))
+	defer t.Stop()
+
+	// In the concurrent mode of WithSkipIfRunning, executions run in their own
+	// goroutine and report their result on results. The execution in flight when
+	// the ticker stops is waited for, and its error is not lost.
+	var running bool
+	results := make(chan error, 1)
+	defer func() {
+		if running {
+			err = joinStop(s.shutdown(ctx, results), err)
+		}
+	}()
+
+	// canceled stops the ticker once the context is done. The final execution of
+	// WithFinalTick waits for the execution in flight.
+	canceled := func() error {
+		if running && s.c.FinalTick {
+			running = false
+			return joinStop(s.shutdown(ctx, results), s.canceled(ctx))
+		}
+		return s.canceled(ctx)
+	}
+
+	// behind is the number of consecutive missed ticks fired to catch up.
+	var behind int
+
+	// fired is the time of the last execution, used to rate-limit the triggers of
+	// WithTrigger. It is the zero time before the first one.
+	var fired time.Time
+	if s.stats.Executions > 0 {
+		fired = prev
+	}
+	trigger := s.c.Trigger
+
+	// scheduled is the intended time of the pending tick, before it is moved to now
+	// when it is late.
+	scheduled := next
+
+	for !s.exhausted() {
+		select {
+		case <-t.C():
+		case e := <-results:
+			running = false
+			if e != nil {
+				return joinStop(e, s.contextErr(ctx))
+			}
+			continue
+		case e := <-s.asyncErr:
+			return joinStop(e, s.contextErr(ctx))
+		case <-s.limitc:
+			continue
+		case <-s.resetc:
+			// Reschedule the pending tick to the new interval after the previous tick.
+			if !t.Stop() {
+				<-t.C()
+			}
+			next = prev.Add(s.c.floor(s.interval()))
+			scheduled = next
+			now := clock.Now()
+			if next.Before(now) {
+				next = now
+			}
+			s.setNext(next)
+			t.Reset(next.Sub(now))
+			continue
+		case <-ctx.Done():
+			return canceled()
+		case _, ok := <-trigger:
+			if !ok {
+				trigger = nil
+				continue
+			}
+			// A trigger within an interval of the last execution is coalesced into
+			// the pending tick.
+			now := clock.Now()
+			if !fired.IsZero() && now.Before(fired.Add(s.interval())) {
+				continue
+			}
+			if !t.Stop() {
+				<-t.C()
+			}
+			next, scheduled = now, now
+		case <-s.stop:
+			return nil
+		case <-s.c.StopChan:
+			return nil
+		case <-s.c.StopSignal:
+			return ErrStopped
+		case <-end:
+			return nil
+		}
+		// The select may pick a tick or a trigger over a context done at the same time.
+		if ctx.Err() != nil {
+			return canceled()
+		}
+		if s.signaled() {
+			return ErrStopped
+		}
+		if s.c.LagObserver != nil {
+			now := clock.Now()
+			s.c.LagObserver(scheduled, now, now.Sub(scheduled))
+		}
+		switch {
+		case !s.ready():
+		case s.c.Async:
+			if !s.take() {
+				return nil
+			}
+			fired = clock.Now()
+			s.launch(ctx)
+		case !s.c.SkipIfRunning:
+			if !s.take() {
+				return nil
+			}
+			fired = clock.Now()
+			if err := s.execBounded(ctx); err != nil {
+				return joinStop(err, s.contextErr(ctx))
+			}
+		case running:
+			s.mu.Lock()
+			s.stats.Skipped++
+			s.mu.Unlock()
+		case !s.take():
+			return nil
+		default:
+			running = true
+			fired = clock.Now()
+			go func() { results <- s.exec(ctx) }()
+		}
+		// Like time.Ticker, drop the ticks missed while the task was running,
+		// unless they should be caught up. With a fixed delay, nothing is missed.
+		now := clock.Now()
+		if s.c.FixedDelay && s.sched == nil {
+			prev = now
+		} else {
+			prev = next
+		}
+		next = s.after(prev)
+		if next.IsZero() {
+			// The schedule has no more ticks, such as at the end of RunSequence.
+			s.halt()
+			return nil
+		}
+		scheduled = next
+		if next.Before(now) && s.c.Saturation != nil {
+			s.c.Saturation(now.Sub(next))
+		}
+		if !next.Before(now) {
+			behind = 0
+		} else if s.c.CatchUp && (s.c.MaxCatchUp <= 0 || behind < s.c.MaxCatchUp) {
+			behind++
+		} else {
+			if n := s.dropped(next, now); n > 0 {
+				s.mu.Lock()
+				s.stats.Dropped += n
+				s.mu.Unlock()
+			}
+			next = now
+			behind = 0
+		}
+		s.setNext(next)
+		t.Reset(next.Sub(now))
+	}
+	return nil
+}
+
+// finish reports that the ticker stopped with err to the callbacks of WithOnStop and
+// WithObservers, and returns err annotated with the name of WithName, if any.
+func (s *session) finish(err error) error {
+	if err != nil && s.c.Name != "" {
+		err = fmt.Errorf("ticker %q: %w", s.c.Name, err)
+	}
+	if len(s.c.Observers) > 0 {
+		s.notify(func(obs Observer) { obs.OnStop(err) })
+	}
+	if s.c.OnStop != nil {
+		s.c.OnStop(err)
+	}
+	return err
+}
+
+// withValues returns ctx with the values of WithContextValue.
+func (s *session) withValues(ctx context.Context) context.Context {
+	for _, kv := range s.c.Values {
+		ctx = context.WithValue(ctx, kv.key, kv.value)
+	}
+	return ctx
+}
+
+// startDelay waits for the delay of WithStartDelay. It reports false, along with the
+// error to return, if the ticker stops in the meantime.
+func (s *session) startDelay(ctx context.Context, end <-chan time.Time) (bool, error) {
+	t := s.c.Clock.NewTimer(s.c.jitter(s.c.StartDelay))
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return true, nil
+	case <-ctx.Done():
+		return false, s.contextErr(ctx)
+	case <-s.stop:
+	case <-s.c.StopChan:
+	case <-s.c.StopSignal:
+		return false, ErrStopped
+	case <-end:
+	}
+	return false, nil
+}
+
+// signaled reports whether the signal of WithStopSignal has been received, without
+// waiting for it.
+func (s *session) signaled() bool {
+	select {
+	case <-s.c.StopSignal:
+		return true
+	default:
+		return false
+	}
+}
+
+// dropped returns the number of ticks due after the tick at next and up to now, which
+// are dropped when the tick at next fires late at now.
+func (s *session) dropped(next, now time.Time) int {
+	if s.sched != nil {
+		n := 0
+		for t := s.sched(next); !t.IsZero() && !t.After(now); t = s.sched(t) {
+			n++
+		}
+		return n
+	}
+	if iv := s.interval(); iv > 0 {
+		return int(now.Sub(next) / iv)
+	}
+	return 0
+}
+
+// deadline returns the time at which the ticker stops by WithMaxDuration or
+// WithEndTime, whichever comes first, and reports whether there is one.
+func (s *session) deadline() (time.Time, bool) {
+	deadline := s.c.EndTime
+	if s.c.MaxDuration > 0 {
+		if t := s.c.Clock.Now().Add(s.c.MaxDuration); deadline.IsZero() || t.Before(deadline) {
+			deadline = t
+		}
+	}
+	return deadline, !deadline.IsZero()
+}
+
+// contextErr returns the error of ctx, replaced as configured by WithDeadlineError and
+// WithContextErrorAsNil.
+func (s *session) contextErr(ctx context.Context) error {
+	if s.c.CtxErrAsNil {
+		return nil
+	}
+	err := ctx.Err()
+	if s.c.DeadlineError != nil && errors.Is(err, context.DeadlineExceeded) {
+		return s.c.DeadlineError.err
+	}
+	return err
+}
+
+// canceled returns the error to return once ctx is done, after the final execution of
+// WithFinalTick, if set.
+func (s *session) canceled(ctx context.Context) error {
+	if !s.c.FinalTick {
+		return s.contextErr(ctx)
+	}
+	return s.finalTick(ctx)
+}
+
+// finalTick executes the task once more after ctx is done, as configured by
+// WithFinalTick, and returns its error joined with the context error.
+func (s *session) finalTick(ctx context.Context) error {
+	d := s.c.Timeout
+	if d <= 0 {
+		d = s.base()
+	}
+	fctx := context.WithoutCancel(ctx)
+	if d > 0 {
+		var cancel context.CancelFunc
+		fctx, cancel = context.WithTimeout(fctx, d)
+		defer cancel()
+	}
+	s.reuse(fctx)
+	if s.c.Shutdown <= 0 {
+		return joinStop(s.exec(fctx), s.contextErr(ctx))
+	}
+	results := make(chan error, 1)
+	go func() { results <- s.exec(fctx) }()
+	return joinStop(s.shutdown(ctx, results), s.contextErr(ctx))
+}
+
+// execBounded executes the task like exec, but once a stop is requested, waits for it
+// no longer than set by WithShutdownTimeout.
+func (s *session) execBounded(ctx context.Context) error {
+	if s.c.Shutdown <= 0 {
+		return s.exec(ctx)
+	}
+	results := make(chan error, 1)
+	go func() { results <- s.exec(ctx) }()
+	select {
+	case err := <-results:
+		return err
+	case <-ctx.Done():
+	case <-s.stop:
+	}
+	return s.shutdown(ctx, results)
+}
+
+// shutdown waits for the result of the execution in flight after a stop was requested,
+// for at most the duration of WithShutdownTimeout, if set. It returns
+// ErrShutdownTimeout, joined with the error of ctx, if the execution is abandoned.
+func (s *session) shutdown(ctx context.Context, results <-chan error) error {
+	if s.c.Shutdown <= 0 {
+		return <-results
+	}
+	t := s.c.Clock.NewTimer(s.c.Shutdown)
+	defer t.Stop()
+	select {
+	case err := <-results:
+		return err
+	case <-t.C():
+		return joinStop(ErrShutdownTimeout, s.contextErr(ctx))
+	}
+}
+
+// joinStop combines the error err of the last execution with the cause that stopped
+// the ticker at the same time, such as a context error, so that neither is lost.
+// The error of the execution comes first.
+func joinStop(err, cause error) error {
+	switch {
+	case err == nil:
+		return cause
+	case cause == nil || errors.Is(err, cause):
+		return err
+	}
+	return errors.Join(err, cause)
+}
+
+// first returns the time of the first tick for a ticker started at now, delayed by
+// the jitter of WithInitialJitter, if any.
+func (s *session) first(now time.Time) time.Time {
+	next := s.firstTick(now)
+	if s.c.InitialJitter > 0 {
+		next = next.Add(time.Duration(s.c.random() * float64(s.c.InitialJitter)))
+	}
+	return next
+}
+
+// firstTick returns the time of the first tick for a ticker started at now.
+func (s *session) firstTick(now time.Time) time.Time {
+	if s.sched != nil {
+		return s.sched(now)
+	}
+	if s.c.FirstInterval != nil {
+		return now.Add(*s.c.FirstInterval)
+	}
+	if s.c.Align || s.c.WallClock {
+		next := s.aligned(now)
+		// A start within the tolerance after an aligned time counts as on time.
+		if prev := next.Add(-s.base()); s.c.AlignTol > 0 && now.Sub(prev) <= s.c.AlignTol {
+			return prev
+		}
+		return next
+	}
+	return now.Add(s.c.floor(s.c.jitter(s.interval())))
+}
+
+// aligned returns the first time after now that is aligned to the base interval since
+// the epoch of WithAlignTo, or the zero time by default.
+func (s *session) aligned(now time.Time) time.Time {
+	d := s.base()
+	if s.c.Epoch.IsZero() {
+		return now.Truncate(d).Add(d)
+	}
+	// The offset is truncated toward the epoch, that is, rounded up if negative.
+	offset := now.Sub(s.c.Epoch)
+	next := s.c.Epoch.Add(offset / d * d)
+	if !next.After(now) {
+		next = next.Add(d)
+	}
+	return next
+}
+
+// after returns the time of the tick following the tick at prev.
+func (s *session) after(prev time.Time) time.Time {
+	if s.sched != nil {
+		return s.sched(prev)
+	}
+	if s.c.WallClock {
+		// Without the monotonic clock reading, the timer is set from the wall clock.
+		now := s.c.Clock.Now().Round(0)
+		next := s.aligned(now)
+		// A tick that fired within the tolerance before an aligned time was the
+		// tick of that time.
+		if s.c.AlignTol > 0 && next.Sub(now) <= s.c.AlignTol {
+			next = next.Add(s.base())
+		}
+		return next
+	}
+	return prev.Add(s.c.floor(s.c.jitter(s.interval())))
+}
+
+// ready reports whether the task should be executed on the current tick.
+func (s *session) ready() bool {
+	if s.paused.Load() {
+		return false
+	}
+	if s.c.Window != nil && !s.c.Window.contains(s.c.Clock.Now()) {
+		return false
+	}
+	return s.c.Gate == nil || s.c.Gate()
+}
+
+// take consumes one execution from the limit and reports whether the execution is
+// allowed.
+func (s *session) take() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit == 0 || s.halted || s.overBudget() {
+		return false
+	}
+	if s.limit > 0 {
+		s.limit--
+	}
+	return true
+}
+
+// exhausted reports whether no execution is left.
+func (s *session) exhausted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limit == 0 || s.halted || s.overBudget()
+}
+
+// halt stops the ticker before any further execution.
+func (s *session) halt() {
+	s.mu.Lock()
+	s.halted = true
+	s.mu.Unlock()
+}
+
+// overBudget reports whether the budget of WithTotalExecBudget is exceeded. s.mu must
+// be held.
+func (s *session) overBudget() bool {
+	return s.c.ExecBudget > 0 && s.spent > s.c.ExecBudget
+}
+
+// setNext records the time of the pending tick.
+func (s *session) setNext(next time.Time) {
+	s.mu.Lock()
+	s.next = next
+	s.mu.Unlock()
+}
+
+// base returns the base interval.
+func (s *session) base() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d
+}
+
+// interval returns the current interval.
+func (s *session) interval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.iv
+}
+
+// exec waits for the rate limiter and the semaphore, if any, executes the task once,
+// updates the current interval, and applies the error callback, if any.
+// It returns a non-nil error only if the ticker should stop.
+func (s *session) exec(ctx context.Context) (err error) {
+	if s.c.RateLimiter != nil {
+		if err := s.c.RateLimiter.Wait(ctx); err != nil {
+			if ctx.Err() != nil {
+				return s.contextErr(ctx)
+			}
+			return err
+		}
+	}
+	if s.c.Semaphore != nil {
+		select {
+		case s.c.Semaphore <- struct{}{}:
+			defer func() { <-s.c.Semaphore }()
+		case <-ctx.Done():
+			return s.contextErr(ctx)
+		}
+	}
+	if s.c.PreTick != nil {
+		s.mu.Lock()
+		n := s.stats.Executions + 1
+		s.mu.Unlock()
+		if err := s.c.PreTick(n); err != nil {
+			return fmt.Errorf("%w: %w", ErrPreTickAbort, err)
+		}
+	}
+	s.mu.Lock()
+	s.stats.Executions++
+	n := s.stats.Executions
+	s.mu.Unlock()
+	if s.c.BeforeTick != nil {
+		s.c.BeforeTick(n)
+	}
+	info := tickInfo{n: n, concurrency: s.c.Concurrency}
+	if s.c.ExecID != nil {
+		info.id, info.hasID = s.c.ExecID(n), true
+	}
+	ctx = s.tickContext(ctx, info)
+	start := s.c.Clock.Now()
+	if s.c.TickError {
+		defer func() {
+			if err != nil {
+				err = &TickError{Index: n, At: start, Err: err}
+			}
+		}()
+	}
+	recovered, err := s.retry(ctx)
+	took := s.c.Clock.Now().Sub(start)
+	if s.c.ExecBudget > 0 {
+		s.mu.Lock()
+		s.spent += took
+		s.mu.Unlock()
+	}
+	if s.c.AfterTick != nil {
+		s.c.AfterTick(n, err, took)
+	}
+	if len(s.c.Observers) > 0 {
+		s.notify(func(obs Observer) { obs.OnTick(ctx, n, took, err) })
+		if err != nil {
+			s.notify(func(obs Observer) { obs.OnError(ctx, n, err) })
+		}
+	}
+	if s.c.Logger != nil {
+		s.log(ctx, n, took, err)
+	}
+	if s.c.Async {
+		s.settle.Lock()
+		defer s.settle.Unlock()
+	}
+	if !recovered {
+		s.mu.Lock()
+		d, iv := s.d, s.iv
+		s.mu.Unlock()
+		iv = s.c.interval(d, iv, err, took)
+		s.mu.Lock()
+		if s.d == d {
+			s.iv = iv
+		}
+		s.mu.Unlock()
+	}
+	s.mu.Lock()
+	s.last, s.lastRun = err, start.Add(took)
+	s.mu.Unlock()
+	if err != nil {
+		s.mu.Lock()
+		s.stats.Errors++
+		s.mu.Unlock()
+		if start.Sub(s.start) >= s.c.GracePeriod {
+			s.failures++
+		}
+		s.consecutive++
+		select {
+		case s.c.ErrorChan <- err:
+		default:
+		}
+	} else {
+		s.consecutive = 0
+	}
+	if s.c.Health > 0 {
+		s.unhealthy.Store(s.consecutive >= s.c.Health)
+	}
+	if s.c.FailFastFirst && n == 1 && err != nil && !recovered {
+		return err
+	}
+	if s.until && !recovered {
+		return s.untilNext(err)
+	}
+	if recovered {
+		return err
+	}
+	if err != nil && start.Sub(s.start) < s.c.GracePeriod {
+		return nil
+	}
+	if s.c.Decider != nil {
+		return s.decide(n, err, took)
+	}
+	if err == nil {
+		return nil
+	}
+	stop := err
+	if s.c.OnError != nil {
+		stop = s.c.OnError(err)
+	} else if !s.c.StopOnError {
+		stop = nil
+	}
+	if stop == nil && s.tooManyErrors() {
+		return err
+	}
+	return stop
+}
+
+// tooManyErrors reports whether the limit of WithMaxErrors or WithMaxConsecutiveErrors
+// has been reached.
+func (s *session) tooManyErrors() bool {
+	return s.c.MaxErrors > 0 && s.failures >= s.c.MaxErrors ||
+		s.c.MaxConsErrors > 0 && s.consecutive >= s.c.MaxConsErrors
+}
+
+// retry invokes the task and, if it fails, re-invokes it as configured by WithRetry.
+// It stops retrying when the context is done and returns the last error.
+func (s *session) retry(ctx context.Context) (recovered bool, err error) {
+	recovered, err = s.call(ctx)
+	if s.c.Retry == nil {
+		return recovered, err
+	}
+	for i := 0; i < s.c.Retry.attempts && err != nil && !recovered; i++ {
+		t := s.c.Clock.NewTimer(s.c.Retry.delay)
+		select {
+		case <-t.C():
+		case <-ctx.Done():
+			t.Stop()
+			return recovered, err
+		}
+		recovered, err = s.call(ctx)
+	}
+	return recovered, err
+}
+
+// call invokes the task once, with a per-execution context and timeout if set.
+// If the task panics and a recover handler is set, call reports recovered as true
+// and returns the error returned by the handler.
+func (s *session) call(ctx context.Context) (recovered bool, err error) {
+	var returned bool
+	if s.c.Observer != nil {
+		// Registered first so that it runs after a recovered panic has set err.
+		// An unrecovered panic is not observed.
+		defer func(start time.Time) {
+			if returned || recovered {
+				s.c.Observer(s.c.Clock.Now().Sub(start), err)
+			}
+		}(s.c.Clock.Now())
+	}
+	var finish func(error)
+	if s.c.ContextWrapper != nil {
+		// Registered before the panic handlers so that it sees a recovered panic.
+		defer func() {
+			if finish != nil && (returned || recovered) {
+				finish(err)
+			}
+		}()
+	}
+	if s.c.PanicAsError {
+		// Registered before the recover handler, which takes precedence.
+		defer func() {
+			if v := recover(); v != nil {
+				// The panic is reported like an error returned by the task.
+				returned, err = true, newPanicError(v)
+			}
+		}()
+	}
+	if s.c.Recover != nil {
+		defer func() {
+			if v := recover(); v != nil {
+				recovered, err = true, s.c.Recover(v)
+			}
+		}()
+	}
+	if s.c.ContextWrapper != nil {
+		info, _ := tickFromContext(ctx)
+		ctx, finish = s.c.ContextWrapper(ctx, info.n)
+	}
+	if s.c.PerTickContext != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = s.c.PerTickContext(ctx)
+		if cancel != nil {
+			defer cancel()
+		}
+	}
+	if s.c.Timeout > 0 {
+		parent := ctx
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.c.Timeout)
+		defer cancel()
+		defer func() {
+			// The error of a task that failed past its own timeout, but not because
+			// of the parent context, is marked as a timeout.
+			if err != nil && !recovered && ctx.Err() == context.DeadlineExceeded && parent.Err() == nil {
+				err = fmt.Errorf("%w: %w", ErrTickTimeout, err)
+			}
+		}()
+	}
+	if s.c.TraceRegion == "" {
+		err = s.task(ctx)
+	} else {
+		err = s.traced(ctx)
+	}
+	returned = true
+	return false, err
+}
+
+// tickKey is the context key for the tickInfo of the current execution.
+type tickKey struct{}
+
+// tickInfo describes the current execution.
+type tickInfo struct {
+	// n is the 1-based execution counter.
+	n int
+
+	// concurrency is the concurrency limit set by WithConcurrency.
+	concurrency int
+
+	// id is the ID of the execution set by WithExecID, if hasID is set.
+	id    string
+	hasID bool
+}
+
+// tickFromContext returns the tickInfo of the execution ctx belongs to.
+func tickFromContext(ctx context.Context) (tickInfo, bool) {
+	info, ok := ctx.Value(tickKey{}).(*tickInfo)
+	if !ok {
+		return tickInfo{}, false
+	}
+	return *info, true
+}
+
+// tickContext returns the context of an execution with info, derived from ctx.
+//
+// When the executions cannot overlap, they reuse the context that reuse allocated for
+// ctx, so that the steady loop does not allocate. This is safe because the tickInfo is
+// only read by the package, synchronously within the execution. The IDs of WithExecID
+// may be read by the task at any time, so they always get their own context.
+func (s *session) tickContext(ctx context.Context, info tickInfo) context.Context {
+	if s.c.Async || info.hasID || s.tctx == nil {
+		own := info
+		return context.WithValue(ctx, tickKey{}, &own)
+	}
+	s.tctx.info = info
+	return s.tctx
+}
+
+// reuse allocates the context reused by the following executions, which must all be
+// derived from ctx.
+//
+// Contexts are not compared to detect a change, since a context may be a value of a
+// type that is not comparable.
+func (s *session) reuse(ctx context.Context) {
+	s.tctx = &reusedContext{Context: ctx}
+}
+
+// reusedContext is a context holding the tickInfo of the current execution, reused by
+// the executions of a session.
+type reusedContext struct {
+	context.Context
+	info tickInfo
+}
+
+func (c *reusedContext) Value(key any) any {
+	if key == (tickKey{}) {
+		return &c.info
+	}
+	return c.Context.Value(key)
+}
@@ -0,0 +1,62 @@
+package ticker
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// WithSpin returns an Option to allow an interval of zero, which runs the task again as
+// soon as each execution completes.
+//
+// Without it, an interval of zero is rejected with ErrNonPositiveInterval to avoid
+// accidental busy loops; a negative interval is always rejected. A spinning ticker uses
+// no timer, but checks the context and the other stop conditions between executions, so
+// it stops promptly once they are met. Options that adjust the interval, such as
+// WithBackoff, WithJitter, WithTrigger or Handle.Reset, have no effect while spinning.
+// It yields the processor while paused or gated out. With a positive interval, WithSpin
+// has no effect.
+func WithSpin(v bool) Option {
+	return spin(v)
+}
+
+type spin bool
+
+func (o spin) apply(c *config) {
+	c.Spin = bool(o)
+}
+
+// spin executes the task in a loop without waiting, as configured by WithSpin, until the
+// context is done, the limit is reached or the ticker is stopped.
+func (s *session) spin(ctx context.Context, end <-chan time.Time) error {
+	for !s.exhausted() {
+		select {
+		case <-ctx.Done():
+			return s.canceled(ctx)
+		case <-s.stop:
+			return nil
+		case <-s.c.StopChan:
+			return nil
+		case <-s.c.StopSignal:
+			return ErrStopped
+		case <-end:
+			return nil
+		default:
+		}
+		if !s.ready() {
+			runtime.Gosched()
+			continue
+		}
+		if !s.take() {
+			return nil
+		}
+		if ctx.Err() != nil {
+			// The context was done after the check above, such as by the gate.
+			return s.canceled(ctx)
+		}
+		if err := s.execBounded(ctx); err != nil {
+			return joinStop(err, s.contextErr(ctx))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package ticker
+
+import (
+	"context"
+	"time"
+)
+
+// Stats reports the activity of a ticker.
+type Stats struct {
+	// Executions is the number of times the task was executed.
+	Executions int
+
+	// Errors is the number of executions that returned an error.
+	Errors int
+
+	// Skipped is the number of ticks skipped because the previous execution was still
+	// running; see WithSkipIfRunning.
+	Skipped int
+
+	// Dropped is the number of ticks dropped because an execution outlasted them, like
+	// time.Ticker does for a slow receiver; see WithCatchUp and WithSaturationHandler.
+	Dropped int
+}
+
+// RunStats is like Run, but also returns the Stats of the run.
+//
+// It is useful together with WithStopOnError(false), where task errors do not stop the
+// ticker and would otherwise go unnoticed. With WithAsync, the Stats are a snapshot taken
+// when Run returns, and do not include the outcome of the executions still in flight.
+func (task Task) RunStats(ctx context.Context, d time.Duration, options ...Option) (Stats, error) {
+	s, err := newSession(task, d, options)
+	if err != nil {
+		return Stats{}, err
+	}
+	err = s.run(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats, err
+}
+
+// WithStatsInterval returns an Option to report the Stats of the ticker to fn every d,
+// such as to log how many items were processed in the last minute.
+//
+// fn is called with a snapshot on a dedicated goroutine, at a cadence independent of
+// the ticks, and never concurrently with itself. It stops with the ticker: fn is not
+// called once Run has returned. Run returns an error wrapping ErrInvalidArgument if d
+// is not positive. A nil fn disables the report.
+func WithStatsInterval(d time.Duration, fn func(Stats)) Option {
+	return &statsInterval{d: d, fn: fn}
+}
+
+type statsInterval struct {
+	d  time.Duration
+	fn func(Stats)
+}
+
+func (o *statsInterval) apply(c *config) {
+	c.StatsReport = o
+	if o.fn == nil {
+		c.StatsReport = nil
+	}
+}
+
+// reportStats starts reporting the Stats as set by WithStatsInterval, and returns a
+// function that stops the report and waits for it to finish.
+func (s *session) reportStats() (stop func()) {
+	t := s.c.Clock.NewTicker(s.c.StatsReport.d)
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C():
+			case <-done:
+				return
+			}
+			select {
+			case <-done:
+				// A tick that fires together with the stop is not reported.
+				return
+			default:
+			}
+			s.mu.Lock()
+			stats := s.stats
+			s.mu.Unlock()
+			s.c.StatsReport.fn(stats)
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
@@ -0,0 +1,169 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+	"github.com/goaux/ticker/clocktest"
+)
+
+// TestRunStats tests that RunStats reports executions and errors
+func TestRunStats(t *testing.T) {
+	ErrTask := errors.New("task error")
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		if count%2 == 0 {
+			return ErrTask
+		}
+		return nil
+	})
+
+	stats, err := task.RunStats(context.Background(), 10*time.Millisecond, ticker.WithLimit(5), ticker.WithStopOnError(false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ticker.Stats{Executions: 5, Errors: 2}
+	if stats != want {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+
+	count = 0
+	stats, err = task.RunStats(context.Background(), 10*time.Millisecond, ticker.WithLimit(5))
+	if !errors.Is(err, ErrTask) {
+		t.Errorf("expected error %v, got %v", ErrTask, err)
+	}
+	want = ticker.Stats{Executions: 2, Errors: 1}
+	if stats != want {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+}
+
+// TestWithSkipIfRunning tests that overlapping ticks are skipped and counted
+func TestWithSkipIfRunning(t *testing.T) {
+	var active, overlaps atomic.Int32
+	task := ticker.New(func() error {
+		if active.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(35 * time.Millisecond)
+		active.Add(-1)
+		return nil
+	})
+
+	start := time.Now()
+	stats, err := task.RunStats(context.Background(), 10*time.Millisecond, ticker.WithLimit(3), ticker.WithSkipIfRunning(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Executions != 3 {
+		t.Errorf("expected 3 executions, got %d", stats.Executions)
+	}
+	if stats.Skipped == 0 {
+		t.Error("expected skipped ticks")
+	}
+	if n := overlaps.Load(); n != 0 {
+		t.Errorf("expected no overlapping executions, got %d", n)
+	}
+	if n := active.Load(); n != 0 {
+		t.Errorf("expected Run to wait for the execution in flight, got %d active", n)
+	}
+	// Three executions of 35ms each cannot finish faster than sequentially.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected at least 100ms, got %v", elapsed)
+	}
+}
+
+// TestWithSaturationHandler tests that a task slower than the interval is reported and its missed ticks are dropped
+func TestWithSaturationHandler(t *testing.T) {
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	task := ticker.New(func() error {
+		clock.Advance(3*time.Minute + 30*time.Second)
+		return nil
+	})
+	var lags []time.Duration
+	h := task.Start(context.Background(), time.Minute,
+		ticker.WithClock(clock),
+		ticker.WithLimit(2),
+		ticker.WithSaturationHandler(func(lag time.Duration) {
+			lags = append(lags, lag)
+		}),
+	)
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := fmt.Sprint(lags), "[2m30s 2m30s]"; got != want {
+		t.Errorf("expected lags %s, got %s", want, got)
+	}
+	if got, want := h.Stats(), (ticker.Stats{Executions: 2, Dropped: 4}); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+// TestWithStatsInterval tests that the Stats are reported periodically until the ticker stops
+func TestWithStatsInterval(t *testing.T) {
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	reports := make(chan ticker.Stats, 10)
+	task := ticker.New(func() error { return nil })
+	h := task.Start(context.Background(), time.Minute,
+		ticker.WithClock(clock),
+		ticker.WithStatsInterval(45*time.Second, func(s ticker.Stats) { reports <- s }),
+	)
+	clock.BlockUntil(2)
+	clock.Advance(45 * time.Second)
+	if s := <-reports; s.Executions != 0 {
+		t.Errorf("expected 0 executions at 45s, got %+v", s)
+	}
+	clock.Advance(15 * time.Second)
+	clock.BlockUntil(2)
+	clock.Advance(30 * time.Second)
+	if s := <-reports; s.Executions != 1 {
+		t.Errorf("expected 1 execution at 90s, got %+v", s)
+	}
+
+	h.Stop()
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Advance(time.Hour)
+	select {
+	case s := <-reports:
+		t.Errorf("expected no report after the ticker stopped, got %+v", s)
+	default:
+	}
+
+	if err := ticker.Validate(time.Second, ticker.WithStatsInterval(0, func(ticker.Stats) {})); !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+// TestRunStats_Async tests that RunStats does not race with the executions still in flight
+func TestRunStats_Async(t *testing.T) {
+	release := make(chan struct{})
+	completed := make(chan struct{})
+	task := ticker.New(func() error {
+		<-release
+		defer close(completed)
+		return errors.New("task error")
+	})
+	stats, err := task.RunStats(context.Background(), time.Millisecond,
+		ticker.WithAsync(true),
+		ticker.WithLimit(1),
+		ticker.WithStopOnError(false),
+	)
+	close(release)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Errors != 0 {
+		t.Errorf("expected the execution in flight not to be counted, got %+v", stats)
+	}
+	<-completed
+}
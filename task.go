@@ -15,99 +15,195 @@ import (
 )
 
 // Task represents a function that can be executed periodically.
-type Task func() error
+//
+// The context passed to the function is the one given to Run, so the function can
+// observe cancellation and return early.
+type Task func(context.Context) error
 
 // New creates a new Task from the given task function.
 // It returns a Task type that can be used with the Run method for periodic execution.
 // If a nil function is provided, New returns nil.
 func New(task func() error) Task {
+	if task == nil {
+		return nil
+	}
+	return func(context.Context) error {
+		return task()
+	}
+}
+
+// NewContext creates a new Task from the given context-aware task function.
+// The function receives the context that Run is driving, so it can return early
+// when the context is canceled.
+// If a nil function is provided, NewContext returns nil.
+func NewContext(task func(context.Context) error) Task {
 	return Task(task)
 }
 
+// NewIndexed creates a new Task from a task function that receives the 1-based
+// execution counter.
+//
+// The counter n is 1 for the first execution, including the immediate one requested by
+// WithImmediate, and matches Stats.Executions after the execution. Ticks skipped while
+// paused or gated out are not counted. A task that is not run by a ticker sees n = 1.
+// If a nil function is provided, NewIndexed returns nil.
+func NewIndexed(task func(n int) error) Task {
+	if task == nil {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		info, ok := tickFromContext(ctx)
+		if !ok {
+			info.n = 1
+		}
+		return task(info.n)
+	}
+}
+
+// NewArg creates a new Task from a task function that receives arg, bound when the
+// Task is created.
+//
+// It reads better than a closure and, as arg is evaluated once, avoids the capture of
+// a loop variable that changes afterwards. If a nil function is provided, NewArg
+// returns nil.
+func NewArg[T any](task func(T) error, arg T) Task {
+	if task == nil {
+		return nil
+	}
+	return func(context.Context) error {
+		return task(arg)
+	}
+}
+
+// NewContextArg is like NewArg for a context-aware task function, which receives the
+// context that Run is driving along with arg. If a nil function is provided,
+// NewContextArg returns nil.
+func NewContextArg[T any](task func(context.Context, T) error, arg T) Task {
+	if task == nil {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		return task(ctx, arg)
+	}
+}
+
 // Run executes the task periodically according to the specified duration and options.
 //
 // It returns an error if the task encounters an error or if the context is canceled.
-// The duration d must be greater than zero; if not, Run returns ErrNonPositiveInterval.
+// The duration d must be greater than zero, or zero with WithSpin; if not, Run returns
+// ErrNonPositiveInterval.
 //
 // Options can be used to customize the behavior:
 //   - WithImmediate: Execute the task immediately before starting the ticker.
+//   - WithBurst: Execute the task several times immediately before starting the ticker.
 //   - WithLimit: Limit the number of executions.
+//   - WithOnLimitReached: Be notified when the limit of executions is reached.
+//   - WithOnError: Decide whether to continue or stop when the task fails.
+//   - WithBackoff: Grow the interval while the task keeps failing.
+//   - WithJitteredBackoff: Grow the interval with randomization while the task keeps failing.
+//   - WithErrorBackoff: Delay the next execution after an error only.
+//   - WithJitter: Randomize each interval.
+//   - WithInitialJitter: Delay the first tick randomly.
+//   - WithInterval: Adjust the interval after each execution.
+//   - WithAdaptiveToLatency: Adapt the interval to the duration of the executions.
+//   - WithMinInterval: Set a lower bound on the interval.
+//   - WithRecover: Recover from a panicking task.
+//   - WithMiddleware: Wrap the task with reusable middlewares.
+//   - WithTimeout: Bound each execution of the task.
+//   - WithContextWrapper: Wrap each execution, for example in a tracing span.
+//   - WithExecID: Tag each execution with an ID for correlation.
+//   - WithObservers: Deliver the events of the ticker to several observers.
+//   - WithTraceRegion: Annotate each execution with a trace region and a pprof label.
+//   - WithMaxDuration: Limit the total run time.
+//   - WithTotalExecBudget: Limit the total time spent executing the task.
+//   - WithEndTime: Stop at an absolute time.
+//   - WithStopChan: Stop cleanly when a channel is closed.
+//   - WithStopSignal: Stop with ErrStopped when a channel is signaled.
+//   - WithFinalTick: Execute the task one last time when the context is canceled.
+//   - WithShutdownTimeout: Bound the wait for the executions in flight on shutdown.
+//   - WithTrigger: Execute the task early on demand, at most once per interval.
+//   - WithRetry: Retry a failed execution within the same tick.
+//   - WithStopOnError: Ignore task errors instead of stopping.
+//   - WithMaxErrors, WithMaxConsecutiveErrors: Stop after too many ignored errors.
+//   - WithGracePeriod: Ignore errors for a while after the ticker starts.
+//   - WithDecider: Decide whether to continue after each execution.
+//   - WithSkipIfRunning: Keep the schedule and skip ticks while the task is running.
+//   - WithAsync: Run each execution in its own goroutine, without waiting for it.
+//   - WithFirstInterval: Set the delay before the first tick.
+//   - WithStartDelay: Wait once before the ticker starts, including the immediate executions.
+//   - WithGate: Skip ticks while a condition does not hold.
+//   - WithActiveWindow: Skip ticks outside a window of time every day.
+//   - WithPreTick: Stop with an error when a check fails before an execution.
+//   - WithRateLimiter: Wait for a shared rate limiter before each execution.
+//   - WithCatchUp: Fire the ticks missed during a long execution.
+//   - WithStatsInterval: Report the Stats periodically.
+//   - WithSaturationHandler: Be notified when the task cannot keep up with the interval.
+//   - WithWallClock: Realign the ticks to the wall clock after each execution.
+//   - WithFixedDelay: Wait the interval after each execution instead of ticking at a fixed rate.
+//   - WithLockOSThread: Run every execution on the same OS thread.
+//   - WithSpin: Allow an interval of zero to execute the task continuously.
 //
 // If no error occurs, Run will continue until the context is canceled or, if specified,
-// the execution limit is reached.
+// the execution limit, the maximum duration or the end time is reached.
+//
+// If the context is already done when Run is called, Run returns the context error
+// without executing the task, even with WithImmediate, WithFinalTick or a tick that is
+// due at once. Once the context is done, no further tick or trigger is executed.
+//
+// An execution in progress always completes before Run returns, except with WithAsync
+// and WithShutdownTimeout.
+// If the last execution fails with an error that stops the ticker while the context is
+// done, Run returns both errors joined with errors.Join, the task error first, so that
+// errors.Is matches either.
+//
+// Once the ticker is running, a tick does not allocate, unless an option needs a
+// context or a goroutine for each execution, such as WithTimeout, WithAsync or
+// WithExecID. This makes Run suitable for hot paths with many tickers.
 func (task Task) Run(ctx context.Context, d time.Duration, options ...Option) error {
-	if d <= 0 {
-		return ErrNonPositiveInterval
-	}
-
-	if task == nil {
-		return ErrNilFunction
-	}
-
-	c := &config{
-		Limit: -1,
-	}
-	for _, opt := range options {
-		opt.apply(c)
+	s, err := newSession(task, d, options)
+	if err != nil {
+		return err
 	}
+	return s.run(ctx)
+}
 
-	if c.Limit == 0 {
-		return nil
-	}
-	if c.Limit > 0 {
-		return task.runLimit(ctx, d, c)
+// Validate checks the interval d and the options like Run does, without executing
+// anything, and returns the first violation, if any.
+//
+// It returns ErrNonPositiveInterval for a non-positive d, unless it is zero with
+// WithSpin, one of the errors wrapping ErrInvalidArgument for an invalid option, or
+// ErrConflictingOptions for options that cannot be combined, such as WithFixedDelay and
+// WithCatchUp. It helps surface configuration bugs at startup, before the first tick.
+func Validate(d time.Duration, options ...Option) error {
+	c, err := newConfig(options)
+	if d < 0 || d == 0 && err == nil && !c.Spin {
+		return ErrNonPositiveInterval
 	}
-	return task.run(ctx, d, c)
+	return err
 }
 
-// runLimit executes the task for a limited number of times or until the context is canceled.
-// It respects the immediate execution option and returns early if the limit is reached.
-func (task Task) runLimit(ctx context.Context, d time.Duration, c *config) error {
-	limit := c.Limit
-	if c.Immediate {
-		if err := task(); err != nil {
-			return err
-		}
-		limit--
-		if limit == 0 {
-			return nil
-		}
-	}
-	t := time.NewTicker(d)
-	defer t.Stop()
-	for ; limit > 0; limit-- {
-		select {
-		case <-t.C:
-			if err := task(); err != nil {
-				return err
-			}
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+// RunN executes the task exactly n times, unless an error occurs or the context is
+// canceled first, and then returns.
+//
+// It is a shorthand for Run with WithLimit(n), which overrides any WithLimit in options.
+// The nth execution is the last; with WithImmediate, the immediate execution is the first
+// of the n. RunN returns ErrNonPositiveCount if n is not positive.
+func (task Task) RunN(ctx context.Context, d time.Duration, n int, options ...Option) error {
+	if n <= 0 {
+		return ErrNonPositiveCount
 	}
-	return nil
+	return task.Run(ctx, d, append(options[:len(options):len(options)], WithLimit(n))...)
 }
 
-// run executes the task indefinitely or until the context is canceled.
-// It respects the immediate execution option.
-func (task Task) run(ctx context.Context, d time.Duration, c *config) error {
-	if c.Immediate {
-		if err := task(); err != nil {
-			return err
-		}
-	}
-	t := time.NewTicker(d)
-	defer t.Stop()
-	for {
-		select {
-		case <-t.C:
-			if err := task(); err != nil {
-				return err
-			}
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+// RunOnce executes the task once with a background context and returns its error.
+//
+// It goes through the same validation as Run, so it returns ErrNilFunction if the task
+// is nil. No option applies; the task sees execution counter 1 with NewIndexed.
+func (task Task) RunOnce() error {
+	if task == nil {
+		return ErrNilFunction
 	}
+	return task(context.Background())
 }
 
 var (
@@ -127,4 +223,53 @@ var (
 	// ErrNilFunction indicates that a nil function was provided.
 	// This error wraps ErrInvalidArgument, so errors.Is(ErrNilFunction, ErrInvalidArgument) will return true.
 	ErrNilFunction = fmt.Errorf("%w: function must not be nil", ErrInvalidArgument)
+
+	// ErrNonPositiveCount indicates that a non-positive execution count was provided.
+	// This error wraps ErrInvalidArgument, so errors.Is(ErrNonPositiveCount, ErrInvalidArgument) will return true.
+	ErrNonPositiveCount = fmt.Errorf("%w: non-positive count", ErrInvalidArgument)
+
+	// ErrInvalidBackoff indicates that invalid backoff parameters were provided.
+	// This error wraps ErrInvalidArgument, so errors.Is(ErrInvalidBackoff, ErrInvalidArgument) will return true.
+	ErrInvalidBackoff = fmt.Errorf("%w: invalid backoff", ErrInvalidArgument)
+
+	// ErrInvalidJitter indicates that a jitter fraction outside of [0, 1] was provided.
+	// This error wraps ErrInvalidArgument, so errors.Is(ErrInvalidJitter, ErrInvalidArgument) will return true.
+	ErrInvalidJitter = fmt.Errorf("%w: jitter fraction must be within [0, 1]", ErrInvalidArgument)
+
+	// ErrInvalidBurst indicates that a negative burst count was provided.
+	// This error wraps ErrInvalidArgument, so errors.Is(ErrInvalidBurst, ErrInvalidArgument) will return true.
+	ErrInvalidBurst = fmt.Errorf("%w: negative burst", ErrInvalidArgument)
+
+	// ErrInvalidRetry indicates that invalid retry parameters were provided.
+	// This error wraps ErrInvalidArgument, so errors.Is(ErrInvalidRetry, ErrInvalidArgument) will return true.
+	ErrInvalidRetry = fmt.Errorf("%w: invalid retry", ErrInvalidArgument)
+
+	// ErrConflictingOptions indicates that options that cannot be combined were provided.
+	// This error wraps ErrInvalidArgument, so errors.Is(ErrConflictingOptions, ErrInvalidArgument) will return true.
+	ErrConflictingOptions = fmt.Errorf("%w: conflicting options", ErrInvalidArgument)
+
+	// ErrTickTimeout indicates that an execution failed after exceeding the timeout set
+	// by WithTimeout. The error of the execution wraps both ErrTickTimeout and the error
+	// returned by the task.
+	ErrTickTimeout = errors.New("ticker: tick timed out")
+
+	// ErrStopped indicates that the ticker was stopped by the signal set by WithStopSignal.
+	ErrStopped = errors.New("ticker: stopped by signal")
+
+	// ErrRunning indicates that Handle.Restart was called on a ticker that is still running.
+	ErrRunning = errors.New("ticker: still running")
+
+	// ErrShutdownTimeout indicates that an execution in flight did not complete within
+	// the timeout set by WithShutdownTimeout once a stop was requested, and was
+	// abandoned.
+	ErrShutdownTimeout = errors.New("ticker: shutdown timed out")
+
+	// ErrPreTickAbort indicates that the ticker was stopped by the check set by
+	// WithPreTick. The error returned by Run wraps both ErrPreTickAbort and the error
+	// of the check.
+	ErrPreTickAbort = errors.New("ticker: aborted before tick")
+
+	// ErrInvalidCron indicates that a malformed cron expression was provided.
+	// This error wraps ErrInvalidArgument, so errors.Is(ErrInvalidCron, ErrInvalidArgument) will return true.
+	ErrInvalidCron = fmt.Errorf("%w: invalid cron expression", ErrInvalidArgument)
 )
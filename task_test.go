@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/goaux/ticker"
+	"github.com/goaux/ticker/clocktest"
 )
 
 func Example() {
@@ -83,6 +86,7 @@ func TestTask_Run(t *testing.T) {
 			task:        func() error { return ErrTask },
 			duration:    100 * time.Millisecond,
 			options:     []ticker.Option{ticker.WithImmediate(true)},
+			runTime:     50 * time.Millisecond,
 			expectedErr: ErrTask,
 		},
 	}
@@ -171,3 +175,2596 @@ func TestContextCancellation(t *testing.T) {
 		t.Error("expected at least one execution before cancellation")
 	}
 }
+
+// TestNewContext tests that NewContext passes the running context to the task
+func TestNewContext(t *testing.T) {
+	if task := ticker.NewContext(nil); task != nil {
+		t.Error("NewContext(nil) should return a nil Task")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	task := ticker.NewContext(func(ctx context.Context) error {
+		count++
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := task.Run(ctx, 10*time.Millisecond, ticker.WithImmediate(true))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 execution, got %d", count)
+	}
+}
+
+// TestNewArg tests that NewArg and NewContextArg pass the bound argument to the task
+func TestNewArg(t *testing.T) {
+	if task := ticker.NewArg[string](nil, "a"); task != nil {
+		t.Error("NewArg(nil) should return a nil Task")
+	}
+	if task := ticker.NewContextArg[string](nil, "a"); task != nil {
+		t.Error("NewContextArg(nil) should return a nil Task")
+	}
+
+	var got []string
+	var tasks []ticker.Task
+	for _, name := range []string{"a", "b"} {
+		tasks = append(tasks, ticker.NewArg(func(name string) error {
+			got = append(got, name)
+			return nil
+		}, name))
+	}
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "ctx")
+	tasks = append(tasks, ticker.NewContextArg(func(ctx context.Context, n int) error {
+		got = append(got, fmt.Sprint(ctx.Value(key{}), n))
+		return nil
+	}, 1))
+	for _, task := range tasks {
+		if err := task.RunN(ctx, time.Millisecond, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if fmt.Sprint(got) != "[a b ctx1]" {
+		t.Errorf("expected [a b ctx1], got %v", got)
+	}
+}
+
+// TestWithOnError tests the WithOnError option
+func TestWithOnError(t *testing.T) {
+	ErrTask := errors.New("task error")
+	ErrAbort := errors.New("abort")
+
+	t.Run("Continue", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			return ErrTask
+		})
+		var seen []error
+		onError := func(err error) error {
+			seen = append(seen, err)
+			return nil
+		}
+
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithLimit(3), ticker.WithOnError(onError))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if count != 3 {
+			t.Errorf("expected 3 executions, got %d", count)
+		}
+		if len(seen) != 3 || !errors.Is(seen[0], ErrTask) {
+			t.Errorf("expected 3 task errors, got %v", seen)
+		}
+	})
+
+	t.Run("Abort", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			if count == 2 {
+				return ErrTask
+			}
+			return nil
+		})
+		onError := func(err error) error {
+			return ErrAbort
+		}
+
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithImmediate(true), ticker.WithOnError(onError))
+		if !errors.Is(err, ErrAbort) {
+			t.Errorf("expected error %v, got %v", ErrAbort, err)
+		}
+		if count != 2 {
+			t.Errorf("expected 2 executions, got %d", count)
+		}
+	})
+}
+
+// TestWithBackoff tests the WithBackoff option
+func TestWithBackoff(t *testing.T) {
+	ErrTask := errors.New("task error")
+
+	t.Run("Invalid", func(t *testing.T) {
+		task := ticker.New(func() error { return nil })
+		for _, opt := range []ticker.Option{
+			ticker.WithBackoff(time.Second, time.Second, 1),
+			ticker.WithBackoff(2*time.Second, time.Second, 2),
+			ticker.WithBackoff(0, time.Second, 2),
+		} {
+			err := task.Run(context.Background(), time.Second, opt)
+			if !errors.Is(err, ticker.ErrInvalidBackoff) || !errors.Is(err, ticker.ErrInvalidArgument) {
+				t.Errorf("expected error %v, got %v", ticker.ErrInvalidBackoff, err)
+			}
+		}
+	})
+
+	t.Run("GrowAndReset", func(t *testing.T) {
+		var times []time.Time
+		task := ticker.New(func() error {
+			times = append(times, time.Now())
+			if len(times) <= 2 {
+				return ErrTask
+			}
+			return nil
+		})
+		ignore := func(error) error { return nil }
+
+		err := task.Run(context.Background(), 10*time.Millisecond,
+			ticker.WithImmediate(true),
+			ticker.WithLimit(5),
+			ticker.WithOnError(ignore),
+			ticker.WithBackoff(10*time.Millisecond, 80*time.Millisecond, 4),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(times) != 5 {
+			t.Fatalf("expected 5 executions, got %d", len(times))
+		}
+		// Two failures grow the interval to 40ms and then to the 80ms cap.
+		if gap := times[2].Sub(times[1]); gap < 70*time.Millisecond {
+			t.Errorf("expected backed off interval, got %v", gap)
+		}
+		// The success on the third execution resets the interval.
+		if gap := times[4].Sub(times[3]); gap > 40*time.Millisecond {
+			t.Errorf("expected reset interval, got %v", gap)
+		}
+	})
+}
+
+// TestWithBackoffDecay tests that the interval decays back toward min after failures
+func TestWithBackoffDecay(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		task := ticker.New(func() error { return nil })
+		for _, opt := range []ticker.Option{
+			ticker.WithBackoffDecay(time.Second, time.Minute, 2, 1),
+			ticker.WithBackoffDecay(time.Second, time.Minute, 2, 0),
+			ticker.WithBackoffDecay(time.Second, time.Minute, 0.5, 2),
+		} {
+			err := task.Run(context.Background(), time.Second, opt)
+			if !errors.Is(err, ticker.ErrInvalidBackoff) {
+				t.Errorf("expected error %v, got %v", ticker.ErrInvalidBackoff, err)
+			}
+		}
+	})
+
+	t.Run("Converge", func(t *testing.T) {
+		start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+		clock := clocktest.NewClock(start)
+		executed := make(chan struct{})
+		task := ticker.NewIndexed(func(n int) error {
+			executed <- struct{}{}
+			if n <= 3 {
+				return errors.New("task error")
+			}
+			return nil
+		})
+		h := task.Start(context.Background(), time.Minute,
+			ticker.WithClock(clock),
+			ticker.WithStopOnError(false),
+			ticker.WithBackoffDecay(time.Minute, 16*time.Minute, 2, 2),
+		)
+		defer h.Stop()
+		var got []time.Duration
+		prev := start
+		for i := 0; i < 7; i++ {
+			clock.BlockUntil(1)
+			next := h.NextTick()
+			got = append(got, next.Sub(prev))
+			clock.Set(next)
+			<-executed
+			prev = next
+		}
+		// Three failures grow the interval, and the successes divide it back to min.
+		want := "[1m0s 2m0s 4m0s 8m0s 4m0s 2m0s 1m0s]"
+		if fmt.Sprint(got) != want {
+			t.Errorf("expected intervals %v, got %v", want, got)
+		}
+	})
+}
+
+// TestWithJitter tests the WithJitter option
+func TestWithJitter(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		task := ticker.New(func() error { return nil })
+		for _, frac := range []float64{-0.1, 1.1} {
+			err := task.Run(context.Background(), time.Second, ticker.WithJitter(frac))
+			if !errors.Is(err, ticker.ErrInvalidJitter) || !errors.Is(err, ticker.ErrInvalidArgument) {
+				t.Errorf("WithJitter(%v): expected error %v, got %v", frac, ticker.ErrInvalidJitter, err)
+			}
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		start := time.Now()
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			return nil
+		})
+
+		err := task.Run(context.Background(), 20*time.Millisecond, ticker.WithLimit(5), ticker.WithJitter(0.5))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 5 {
+			t.Errorf("expected 5 executions, got %d", count)
+		}
+		// Five intervals between 10ms and 30ms each.
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("expected at least 50ms, got %v", elapsed)
+		}
+	})
+}
+
+// TestWithRecover tests the WithRecover option
+func TestWithRecover(t *testing.T) {
+	ErrPanic := errors.New("panic")
+
+	t.Run("Continue", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			if count == 2 {
+				panic("boom")
+			}
+			return nil
+		})
+		var recovered []any
+		handler := func(r any) error {
+			recovered = append(recovered, r)
+			return nil
+		}
+
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithLimit(4), ticker.WithRecover(handler))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if count != 4 {
+			t.Errorf("expected 4 executions, got %d", count)
+		}
+		if len(recovered) != 1 || recovered[0] != "boom" {
+			t.Errorf("expected one recovered panic, got %v", recovered)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("expected no leaked goroutines, got %d before and %d after", before, after)
+		}
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			panic("boom")
+		})
+		handler := func(r any) error {
+			return ErrPanic
+		}
+
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithImmediate(true), ticker.WithRecover(handler))
+		if !errors.Is(err, ErrPanic) {
+			t.Errorf("expected error %v, got %v", ErrPanic, err)
+		}
+		if count != 1 {
+			t.Errorf("expected 1 execution, got %d", count)
+		}
+	})
+}
+
+// TestWithTimeout tests the WithTimeout option
+func TestWithTimeout(t *testing.T) {
+	task := ticker.NewContext(func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+
+	start := time.Now()
+	err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithImmediate(true), ticker.WithTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ticker.ErrTickTimeout) {
+		t.Errorf("expected both %v and %v, got %v", context.DeadlineExceeded, ticker.ErrTickTimeout, err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the execution to be bounded, took %v", elapsed)
+	}
+
+	// An error returned before the timeout is not marked as a timeout.
+	ErrTask := errors.New("task error")
+	task = ticker.New(func() error { return ErrTask })
+	err = task.Run(context.Background(), 10*time.Millisecond, ticker.WithTimeout(time.Second))
+	if err != ErrTask {
+		t.Errorf("expected error %v, got %v", ErrTask, err)
+	}
+}
+
+// TestWithInterval tests the WithInterval option
+func TestWithInterval(t *testing.T) {
+	var times []time.Time
+	task := ticker.New(func() error {
+		times = append(times, time.Now())
+		return nil
+	})
+	var calls int
+	next := func(err error) time.Duration {
+		calls++
+		if calls == 1 {
+			return 60 * time.Millisecond
+		}
+		return 0
+	}
+
+	start := time.Now()
+	err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithLimit(3), ticker.WithInterval(next))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(times) != 3 {
+		t.Fatalf("expected 3 executions, got %d", len(times))
+	}
+	if gap := times[0].Sub(start); gap > 50*time.Millisecond {
+		t.Errorf("expected the first interval to be the base interval, got %v", gap)
+	}
+	if gap := times[1].Sub(times[0]); gap < 50*time.Millisecond {
+		t.Errorf("expected the adjusted interval, got %v", gap)
+	}
+	if gap := times[2].Sub(times[1]); gap < 50*time.Millisecond {
+		t.Errorf("expected the adjusted interval to be kept, got %v", gap)
+	}
+}
+
+// TestWithMaxDuration tests the WithMaxDuration option
+func TestWithMaxDuration(t *testing.T) {
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+
+	start := time.Now()
+	err := task.Run(context.Background(), time.Hour, ticker.WithImmediate(true), ticker.WithMaxDuration(100*time.Millisecond))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected a prompt return, took %v", elapsed)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 execution, got %d", count)
+	}
+}
+
+// TestLifecycleCallbacks tests the WithOnStart, WithOnStop, WithBeforeTick and WithAfterTick options
+func TestLifecycleCallbacks(t *testing.T) {
+	ErrTask := errors.New("task error")
+	task := ticker.New(func() error { return ErrTask })
+
+	var events []string
+	options := []ticker.Option{
+		ticker.WithOnStart(func() {
+			events = append(events, "start")
+		}),
+		ticker.WithOnStop(func(err error) {
+			events = append(events, fmt.Sprintf("stop %v", err))
+		}),
+		ticker.WithBeforeTick(func(n int) {
+			events = append(events, fmt.Sprintf("before %d", n))
+		}),
+		ticker.WithAfterTick(func(n int, err error, took time.Duration) {
+			events = append(events, fmt.Sprintf("after %d %v", n, err))
+		}),
+		ticker.WithOnError(func(error) error { return nil }),
+		ticker.WithLimit(2),
+	}
+
+	if err := task.Run(context.Background(), 10*time.Millisecond, options...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"start", "before 1", "after 1 task error", "before 2", "after 2 task error", "stop <nil>"}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("expected events %v, got %v", want, events)
+	}
+
+	events = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := task.Run(ctx, 10*time.Millisecond, options...); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled error, got %v", err)
+	}
+	want = []string{"start", "stop context canceled"}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("expected events %v, got %v", want, events)
+	}
+}
+
+// TestWithAlign tests the WithAlign option
+func TestWithAlign(t *testing.T) {
+	const d = 50 * time.Millisecond
+	var times []time.Time
+	task := ticker.New(func() error {
+		times = append(times, time.Now())
+		return nil
+	})
+
+	err := task.Run(context.Background(), d, ticker.WithLimit(2), ticker.WithAlign(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, at := range times {
+		if offset := at.Sub(at.Truncate(d)); offset > 20*time.Millisecond {
+			t.Errorf("tick %d: expected alignment to %v, got an offset of %v", i, d, offset)
+		}
+	}
+}
+
+// TestWithRetry tests the WithRetry option
+func TestWithRetry(t *testing.T) {
+	ErrTask := errors.New("task error")
+
+	t.Run("Invalid", func(t *testing.T) {
+		task := ticker.New(func() error { return nil })
+		err := task.Run(context.Background(), time.Second, ticker.WithRetry(0, time.Millisecond))
+		if !errors.Is(err, ticker.ErrInvalidRetry) || !errors.Is(err, ticker.ErrInvalidArgument) {
+			t.Errorf("expected error %v, got %v", ticker.ErrInvalidRetry, err)
+		}
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			if count < 3 {
+				return ErrTask
+			}
+			return nil
+		})
+		ticks := 0
+		err := task.Run(context.Background(), 10*time.Millisecond,
+			ticker.WithLimit(1),
+			ticker.WithRetry(2, time.Millisecond),
+			ticker.WithBeforeTick(func(int) { ticks++ }),
+		)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if count != 3 || ticks != 1 {
+			t.Errorf("expected 3 tries in 1 tick, got %d tries in %d ticks", count, ticks)
+		}
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			return ErrTask
+		})
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithImmediate(true), ticker.WithRetry(2, time.Millisecond))
+		if !errors.Is(err, ErrTask) {
+			t.Errorf("expected error %v, got %v", ErrTask, err)
+		}
+		if count != 3 {
+			t.Errorf("expected 3 tries, got %d", count)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			cancel()
+			return ErrTask
+		})
+		err := task.Run(ctx, 10*time.Millisecond, ticker.WithImmediate(true), ticker.WithRetry(5, time.Hour))
+		if !errors.Is(err, ErrTask) {
+			t.Errorf("expected error %v, got %v", ErrTask, err)
+		}
+		if count != 1 {
+			t.Errorf("expected 1 try, got %d", count)
+		}
+	})
+}
+
+// TestWithContextValue tests the WithContextValue option
+func TestWithContextValue(t *testing.T) {
+	type key string
+	var got []any
+	task := ticker.NewContext(func(ctx context.Context) error {
+		got = append(got, ctx.Value(key("a")), ctx.Value(key("b")))
+		return nil
+	})
+
+	err := task.Run(context.Background(), 10*time.Millisecond,
+		ticker.WithLimit(1),
+		ticker.WithContextValue(key("a"), 1),
+		ticker.WithContextValue(key("b"), 2),
+		ticker.WithContextValue(key("a"), 3),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(got) != "[3 2]" {
+		t.Errorf("expected [3 2], got %v", got)
+	}
+}
+
+// TestTask_RunN tests the RunN method
+func TestTask_RunN(t *testing.T) {
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+
+	err := task.RunN(context.Background(), 10*time.Millisecond, 0)
+	if !errors.Is(err, ticker.ErrNonPositiveCount) || !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected error %v, got %v", ticker.ErrNonPositiveCount, err)
+	}
+
+	err = task.RunN(context.Background(), 10*time.Millisecond, 3, ticker.WithLimit(10), ticker.WithImmediate(true))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 executions, got %d", count)
+	}
+}
+
+// TestWithFirstInterval tests the WithFirstInterval option
+func TestWithFirstInterval(t *testing.T) {
+	var times []time.Time
+	task := ticker.New(func() error {
+		times = append(times, time.Now())
+		return nil
+	})
+
+	err := task.Run(context.Background(), time.Second, ticker.WithFirstInterval(0))
+	if !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected error %v, got %v", ticker.ErrNonPositiveInterval, err)
+	}
+
+	start := time.Now()
+	err = task.Run(context.Background(), 60*time.Millisecond, ticker.WithLimit(2), ticker.WithFirstInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gap := times[0].Sub(start); gap > 50*time.Millisecond {
+		t.Errorf("expected the first interval, got %v", gap)
+	}
+	if gap := times[1].Sub(times[0]); gap < 50*time.Millisecond {
+		t.Errorf("expected the regular interval, got %v", gap)
+	}
+}
+
+// TestNewIndexed tests that NewIndexed passes the execution counter to the task
+func TestNewIndexed(t *testing.T) {
+	if ticker.NewIndexed(nil) != nil {
+		t.Error("NewIndexed(nil) should return a nil Task")
+	}
+
+	var got []int
+	task := ticker.NewIndexed(func(n int) error {
+		got = append(got, n)
+		return nil
+	})
+
+	for _, immediate := range []bool{false, true} {
+		got = nil
+		stats, err := task.RunStats(context.Background(), 10*time.Millisecond, ticker.WithLimit(3), ticker.WithImmediate(immediate))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != "[1 2 3]" {
+			t.Errorf("immediate=%v: expected [1 2 3], got %v", immediate, got)
+		}
+		if stats.Executions != got[len(got)-1] {
+			t.Errorf("immediate=%v: expected %d executions, got %d", immediate, got[len(got)-1], stats.Executions)
+		}
+	}
+}
+
+// TestWithGate tests the WithGate option
+func TestWithGate(t *testing.T) {
+	ticks := 0
+	open := func() bool {
+		ticks++
+		// Closed for the immediate tick and the first two ticks, then open.
+		return ticks > 3
+	}
+	var got []int
+	task := ticker.NewIndexed(func(n int) error {
+		got = append(got, n)
+		return nil
+	})
+
+	stats, err := task.RunStats(context.Background(), 10*time.Millisecond,
+		ticker.WithImmediate(true),
+		ticker.WithLimit(2),
+		ticker.WithGate(open),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ticks != 5 {
+		t.Errorf("expected the gate to be checked 5 times, got %d", ticks)
+	}
+	if fmt.Sprint(got) != "[1 2]" || stats.Executions != 2 {
+		t.Errorf("expected 2 executions, got %v and %+v", got, stats)
+	}
+}
+
+// TestRun_LastError tests that an error of the last execution is not shadowed by the context error
+func TestRun_LastError(t *testing.T) {
+	ErrTask := errors.New("task error")
+
+	for _, skip := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+		task := ticker.New(func() error {
+			cancel()
+			time.Sleep(10 * time.Millisecond)
+			return ErrTask
+		})
+
+		err := task.Run(ctx, 5*time.Millisecond, ticker.WithSkipIfRunning(skip))
+		if !errors.Is(err, ErrTask) || !errors.Is(err, context.Canceled) {
+			t.Errorf("skip=%v: expected both %v and %v, got %v", skip, ErrTask, context.Canceled, err)
+		}
+		cancel()
+	}
+}
+
+// TestWithEndTime tests the WithEndTime option
+func TestWithEndTime(t *testing.T) {
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+
+	err := task.Run(context.Background(), time.Hour, ticker.WithImmediate(true), ticker.WithEndTime(time.Now().Add(-time.Second)))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no executions for a past end time, got %d", count)
+	}
+
+	start := time.Now()
+	err = task.Run(context.Background(), 20*time.Millisecond, ticker.WithEndTime(start.Add(50*time.Millisecond)))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected a prompt return, took %v", elapsed)
+	}
+	if count < 1 || count > 2 {
+		t.Errorf("expected 1 or 2 executions, got %d", count)
+	}
+}
+
+// TestWithObserver tests the WithObserver option
+func TestWithObserver(t *testing.T) {
+	ErrTask := errors.New("task error")
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		time.Sleep(20 * time.Millisecond)
+		if count == 2 {
+			panic("boom")
+		}
+		return ErrTask
+	})
+
+	var tooks []time.Duration
+	var errs []error
+	err := task.Run(context.Background(), time.Millisecond,
+		ticker.WithLimit(2),
+		ticker.WithStopOnError(false),
+		ticker.WithRecover(func(any) error { return nil }),
+		ticker.WithObserver(func(took time.Duration, err error) {
+			tooks = append(tooks, took)
+			errs = append(errs, err)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tooks) != 2 {
+		t.Fatalf("expected 2 observations, got %d", len(tooks))
+	}
+	for i, took := range tooks {
+		if took < 20*time.Millisecond || took > 200*time.Millisecond {
+			t.Errorf("observation %d: expected about 20ms, got %v", i, took)
+		}
+	}
+	if !errors.Is(errs[0], ErrTask) || errs[1] != nil {
+		t.Errorf("expected [%v <nil>], got %v", ErrTask, errs)
+	}
+}
+
+// TestWithCatchUp tests the WithCatchUp option
+func TestWithCatchUp(t *testing.T) {
+	run := func(options ...ticker.Option) int {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			if count == 1 {
+				// Miss about 5 ticks.
+				time.Sleep(55 * time.Millisecond)
+			}
+			return nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 75*time.Millisecond)
+		defer cancel()
+		task.Run(ctx, 10*time.Millisecond, options...)
+		return count
+	}
+
+	dropped := run()
+	caughtUp := run(ticker.WithCatchUp(true))
+	capped := run(ticker.WithCatchUp(true), ticker.WithMaxCatchUp(1))
+	if caughtUp < dropped+3 {
+		t.Errorf("expected missed ticks to be caught up, got %d executions versus %d", caughtUp, dropped)
+	}
+	if capped >= caughtUp {
+		t.Errorf("expected the catch-up to be capped, got %d executions versus %d", capped, caughtUp)
+	}
+}
+
+// TestWithFixedDelay tests fixed-rate and fixed-delay scheduling with a slow task
+func TestWithFixedDelay(t *testing.T) {
+	run := func(fixedDelay bool) time.Duration {
+		var times []time.Time
+		task := ticker.New(func() error {
+			times = append(times, time.Now())
+			time.Sleep(30 * time.Millisecond)
+			return nil
+		})
+		err := task.Run(context.Background(), 20*time.Millisecond, ticker.WithLimit(3), ticker.WithFixedDelay(fixedDelay))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return times[2].Sub(times[1])
+	}
+
+	if gap := run(false); gap > 45*time.Millisecond {
+		t.Errorf("fixed rate: expected the next execution right after a slow one, got a gap of %v", gap)
+	}
+	if gap := run(true); gap < 50*time.Millisecond {
+		t.Errorf("fixed delay: expected the full delay after a slow execution, got a gap of %v", gap)
+	}
+}
+
+// TestWithStopChan tests the WithStopChan option
+func TestWithStopChan(t *testing.T) {
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+
+	stop := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(stop)
+	}()
+
+	err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithStopChan(stop))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count == 0 {
+		t.Error("expected at least one execution before the stop")
+	}
+}
+
+// TestWithFailFastFirst tests the WithFailFastFirst option
+func TestWithFailFastFirst(t *testing.T) {
+	ErrTask := errors.New("task error")
+	failOn := 0
+	task := ticker.NewIndexed(func(n int) error {
+		if n == failOn {
+			return ErrTask
+		}
+		return nil
+	})
+	options := []ticker.Option{
+		ticker.WithImmediate(true),
+		ticker.WithLimit(3),
+		ticker.WithStopOnError(false),
+		ticker.WithFailFastFirst(true),
+	}
+
+	failOn = 1
+	stats, err := task.RunStats(context.Background(), 10*time.Millisecond, options...)
+	if !errors.Is(err, ErrTask) || stats.Executions != 1 {
+		t.Errorf("expected %v after 1 execution, got %v after %d", ErrTask, err, stats.Executions)
+	}
+
+	failOn = 2
+	stats, err = task.RunStats(context.Background(), 10*time.Millisecond, options...)
+	if err != nil || stats.Executions != 3 {
+		t.Errorf("expected no error after 3 executions, got %v after %d", err, stats.Executions)
+	}
+}
+
+// TestWithDeadlineError tests the WithDeadlineError option
+func TestWithDeadlineError(t *testing.T) {
+	ErrWindow := fmt.Errorf("%w: window closed", context.DeadlineExceeded)
+	task := ticker.New(func() error { return nil })
+
+	for _, tt := range []struct {
+		name     string
+		option   ticker.Option
+		expected error
+	}{
+		{"Replaced", ticker.WithDeadlineError(ErrWindow), ErrWindow},
+		{"Nil", ticker.WithDeadlineError(nil), nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+			defer cancel()
+			err := task.Run(ctx, 10*time.Millisecond, tt.option)
+			if err != tt.expected {
+				t.Errorf("expected error %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := task.Run(ctx, 10*time.Millisecond, ticker.WithDeadlineError(nil))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation to be unaffected, got %v", err)
+	}
+}
+
+// TestWithPerTickContext tests the WithPerTickContext option
+func TestWithPerTickContext(t *testing.T) {
+	type key struct{}
+	var seen []any
+	task := ticker.NewIndexed(func(n int) error {
+		if n == 2 {
+			panic("boom")
+		}
+		return nil
+	})
+	wrapped := ticker.NewContext(func(ctx context.Context) error {
+		seen = append(seen, ctx.Value(key{}))
+		return task(ctx)
+	})
+
+	created, canceled := 0, 0
+	err := wrapped.Run(context.Background(), 10*time.Millisecond,
+		ticker.WithLimit(3),
+		ticker.WithRecover(func(any) error { return nil }),
+		ticker.WithPerTickContext(func(parent context.Context) (context.Context, context.CancelFunc) {
+			created++
+			ctx, cancel := context.WithCancel(context.WithValue(parent, key{}, created))
+			return ctx, func() {
+				canceled++
+				cancel()
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(seen) != "[1 2 3]" {
+		t.Errorf("expected a fresh context for each tick, got %v", seen)
+	}
+	if created != 3 || canceled != 3 {
+		t.Errorf("expected 3 contexts created and canceled, got %d and %d", created, canceled)
+	}
+}
+
+// TestWithContextWrapper tests that WithContextWrapper wraps and finishes each tick
+func TestWithContextWrapper(t *testing.T) {
+	type key struct{}
+	errTask := errors.New("task error")
+	var seen []any
+	task := ticker.NewContext(func(ctx context.Context) error {
+		seen = append(seen, ctx.Value(key{}))
+		switch ctx.Value(key{}) {
+		case 2:
+			return errTask
+		case 3:
+			panic("boom")
+		}
+		return nil
+	})
+
+	var finished []string
+	err := task.Run(context.Background(), 10*time.Millisecond,
+		ticker.WithLimit(3),
+		ticker.WithStopOnError(false),
+		ticker.WithPanicAsError(true),
+		ticker.WithContextWrapper(func(ctx context.Context, n int) (context.Context, func(error)) {
+			return context.WithValue(ctx, key{}, n), func(err error) {
+				finished = append(finished, fmt.Sprintf("%d:%v", n, err))
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(seen) != "[1 2 3]" {
+		t.Errorf("expected the wrapped context for each tick, got %v", seen)
+	}
+	want := "[1:<nil> 2:task error 3:ticker: recovered panic: boom]"
+	if got := fmt.Sprint(finished); got != want {
+		t.Errorf("expected finishers %s, got %s", want, got)
+	}
+}
+
+// limiterFunc is a RateLimiter backed by a function.
+type limiterFunc func(context.Context) error
+
+func (f limiterFunc) Wait(ctx context.Context) error { return f(ctx) }
+
+// TestWithRateLimiter tests the WithRateLimiter option
+func TestWithRateLimiter(t *testing.T) {
+	t.Run("wait before each execution", func(t *testing.T) {
+		var waits, count int
+		task := ticker.New(func() error {
+			count++
+			if waits != count {
+				t.Errorf("expected Wait before execution %d, got %d waits", count, waits)
+			}
+			return nil
+		})
+		err := task.Run(context.Background(), 10*time.Millisecond,
+			ticker.WithImmediate(true),
+			ticker.WithLimit(3),
+			ticker.WithRateLimiter(limiterFunc(func(context.Context) error {
+				waits++
+				return nil
+			})),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if waits != 3 || count != 3 {
+			t.Errorf("expected 3 waits and 3 executions, got %d and %d", waits, count)
+		}
+	})
+
+	t.Run("error stops", func(t *testing.T) {
+		errLimit := errors.New("limit")
+		var count int
+		task := ticker.New(func() error {
+			count++
+			return nil
+		})
+		var waits int
+		err := task.Run(context.Background(), 10*time.Millisecond,
+			ticker.WithRateLimiter(limiterFunc(func(context.Context) error {
+				waits++
+				if waits == 3 {
+					return errLimit
+				}
+				return nil
+			})),
+		)
+		if !errors.Is(err, errLimit) {
+			t.Errorf("expected limiter error, got %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected 2 executions, got %d", count)
+		}
+	})
+}
+
+// TestWithMaxErrors tests the WithMaxErrors and WithMaxConsecutiveErrors options
+func TestWithMaxErrors(t *testing.T) {
+	// The task fails on every execution but the multiples of 3.
+	newTask := func(count *int) ticker.Task {
+		return ticker.NewIndexed(func(n int) error {
+			*count = n
+			if n%3 == 0 {
+				return nil
+			}
+			return fmt.Errorf("error %d", n)
+		})
+	}
+
+	t.Run("cumulative", func(t *testing.T) {
+		var count int
+		err := newTask(&count).Run(context.Background(), 10*time.Millisecond,
+			ticker.WithStopOnError(false),
+			ticker.WithMaxErrors(3),
+		)
+		if err == nil || err.Error() != "error 4" {
+			t.Errorf("expected the third error, got %v", err)
+		}
+		if count != 4 {
+			t.Errorf("expected 4 executions, got %d", count)
+		}
+	})
+
+	t.Run("consecutive", func(t *testing.T) {
+		var count int
+		err := newTask(&count).Run(context.Background(), 10*time.Millisecond,
+			ticker.WithLimit(6),
+			ticker.WithOnError(func(error) error { return nil }),
+			ticker.WithMaxConsecutiveErrors(3),
+		)
+		if err != nil {
+			t.Errorf("expected no error as successes reset the count, got %v", err)
+		}
+		if count != 6 {
+			t.Errorf("expected 6 executions, got %d", count)
+		}
+
+		err = newTask(&count).Run(context.Background(), 10*time.Millisecond,
+			ticker.WithStopOnError(false),
+			ticker.WithMaxConsecutiveErrors(2),
+		)
+		if err == nil || err.Error() != "error 2" {
+			t.Errorf("expected the second error, got %v", err)
+		}
+	})
+}
+
+// TestWithAlignTo tests the WithAlignTo option with an epoch in the past and the future
+func TestWithAlignTo(t *testing.T) {
+	const d = 15 * time.Minute
+	start := time.Date(2024, 7, 14, 10, 7, 0, 0, time.UTC)
+	want := time.Date(2024, 7, 14, 10, 15, 5, 0, time.UTC)
+	for _, epoch := range []time.Time{
+		time.Date(2024, 7, 14, 9, 0, 5, 0, time.UTC),
+		time.Date(2024, 7, 14, 12, 0, 5, 0, time.UTC),
+		want,
+	} {
+		clock := clocktest.NewClock(start)
+		var at time.Time
+		task := ticker.New(func() error {
+			at = clock.Now()
+			return nil
+		})
+		done := make(chan error)
+		go func() {
+			done <- task.Run(context.Background(), d,
+				ticker.WithLimit(1),
+				ticker.WithClock(clock),
+				ticker.WithAlignTo(epoch),
+			)
+		}()
+		clock.BlockUntil(1)
+		clock.Set(want.Add(-time.Nanosecond))
+		clock.Set(want)
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !at.Equal(want) {
+			t.Errorf("epoch %v: expected the first tick at %v, got %v", epoch, want, at)
+		}
+	}
+}
+
+// TestRunOnce tests that RunOnce executes the task once
+func TestRunOnce(t *testing.T) {
+	ErrTask := errors.New("task error")
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return ErrTask
+	})
+	if err := task.RunOnce(); err != ErrTask {
+		t.Errorf("expected error %v, got %v", ErrTask, err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 execution, got %d", count)
+	}
+
+	if err := ticker.Task(nil).RunOnce(); err != ticker.ErrNilFunction {
+		t.Errorf("expected error %v, got %v", ticker.ErrNilFunction, err)
+	}
+}
+
+// TestWithFinalTick tests the WithFinalTick option
+func TestWithFinalTick(t *testing.T) {
+	t.Run("flush on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var count int
+		var final error
+		task := ticker.NewContext(func(ctx context.Context) error {
+			count++
+			if count == 2 {
+				cancel()
+				return nil
+			}
+			if count == 3 {
+				final = ctx.Err()
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("expected the final execution to have a deadline")
+				}
+			}
+			return nil
+		})
+		err := task.Run(ctx, 10*time.Millisecond, ticker.WithFinalTick(true))
+		if err != context.Canceled {
+			t.Errorf("expected error %v, got %v", context.Canceled, err)
+		}
+		if count != 3 {
+			t.Errorf("expected 3 executions, got %d", count)
+		}
+		if final != nil {
+			t.Errorf("expected a live context for the final execution, got %v", final)
+		}
+	})
+
+	t.Run("error joined", func(t *testing.T) {
+		ErrFlush := errors.New("flush error")
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		task := ticker.NewIndexed(func(n int) error {
+			if n == 3 {
+				return ErrFlush
+			}
+			return nil
+		})
+		err := task.Run(ctx, 20*time.Millisecond,
+			ticker.WithFinalTick(true),
+			ticker.WithTimeout(time.Millisecond),
+		)
+		if !errors.Is(err, ErrFlush) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected both %v and %v, got %v", ErrFlush, context.DeadlineExceeded, err)
+		}
+	})
+}
+
+// TestWithSeed tests that WithSeed makes the jittered intervals reproducible
+func TestWithSeed(t *testing.T) {
+	intervals := func(seed int64) []time.Duration {
+		start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+		clock := clocktest.NewClock(start)
+		executed := make(chan struct{})
+		task := ticker.New(func() error {
+			executed <- struct{}{}
+			return nil
+		})
+		h := task.Start(context.Background(), time.Minute,
+			ticker.WithClock(clock),
+			ticker.WithJitter(0.5),
+			ticker.WithSeed(seed),
+		)
+		defer h.Stop()
+		var ivs []time.Duration
+		prev := start
+		for i := 0; i < 5; i++ {
+			clock.BlockUntil(1)
+			next := h.NextTick()
+			ivs = append(ivs, next.Sub(prev))
+			clock.Set(next)
+			<-executed
+			prev = next
+		}
+		return ivs
+	}
+
+	a, b := intervals(1), intervals(1)
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Errorf("expected identical intervals for identical seeds, got %v and %v", a, b)
+	}
+	if c := intervals(2); fmt.Sprint(a) == fmt.Sprint(c) {
+		t.Errorf("expected different intervals for different seeds, got %v", c)
+	}
+}
+
+// TestRun_Canceled tests that Run with an already canceled context does not execute the task
+func TestRun_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	count := 0
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+	err := task.Run(ctx, 10*time.Millisecond, ticker.WithImmediate(true))
+	if err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+	if count != 0 {
+		t.Errorf("expected no execution, got %d", count)
+	}
+}
+
+// TestRun_CanceledOptions tests that Run with an already canceled context does not
+// execute the task with options that tick at once or after the context is done
+func TestRun_CanceledOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, tt := range []struct {
+		name    string
+		d       time.Duration
+		options func() []ticker.Option
+	}{
+		{"final tick", time.Millisecond, func() []ticker.Option {
+			return []ticker.Option{ticker.WithFinalTick(true)}
+		}},
+		{"align tolerance", time.Millisecond, func() []ticker.Option {
+			return []ticker.Option{ticker.WithAlign(true), ticker.WithAlignTolerance(time.Millisecond)}
+		}},
+		{"trigger", time.Hour, func() []ticker.Option {
+			trigger := make(chan struct{}, 1)
+			trigger <- struct{}{}
+			return []ticker.Option{ticker.WithTrigger(trigger)}
+		}},
+		{"burst", time.Millisecond, func() []ticker.Option {
+			return []ticker.Option{ticker.WithBurst(3), ticker.WithFinalTick(true)}
+		}},
+		{"spin", 0, func() []ticker.Option {
+			return []ticker.Option{ticker.WithSpin(true), ticker.WithFinalTick(true)}
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			task := ticker.New(func() error {
+				count++
+				return nil
+			})
+			// The select of the loop picks among the ready cases at random.
+			for i := 0; i < 50; i++ {
+				if err := task.Run(ctx, tt.d, tt.options()...); err != context.Canceled {
+					t.Fatalf("expected error %v, got %v", context.Canceled, err)
+				}
+			}
+			if count != 0 {
+				t.Errorf("expected no execution, got %d", count)
+			}
+		})
+	}
+
+	t.Run("trigger after cancel", func(t *testing.T) {
+		count := 0
+		for i := 0; i < 50; i++ {
+			clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+			ctx, cancel := context.WithCancel(context.Background())
+			trigger := make(chan struct{}, 1)
+			trigger <- struct{}{}
+			task := ticker.New(func() error {
+				count++
+				// The next trigger is due, and so is the tick, as the context ends.
+				cancel()
+				clock.Advance(2 * time.Hour)
+				select {
+				case trigger <- struct{}{}:
+				default:
+				}
+				return nil
+			})
+			err := task.Run(ctx, time.Hour, ticker.WithClock(clock), ticker.WithTrigger(trigger))
+			if err != context.Canceled {
+				t.Fatalf("expected error %v, got %v", context.Canceled, err)
+			}
+		}
+		if count != 50 {
+			t.Errorf("expected 50 executions, got %d", count)
+		}
+	})
+}
+
+// TestWithWallClock tests that WithWallClock realigns the ticks after a clock step
+func TestWithWallClock(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 30, 0, time.UTC)
+	for _, tt := range []struct {
+		wall bool
+		want []time.Time
+	}{
+		{false, []time.Time{
+			time.Date(2024, 7, 14, 10, 1, 0, 0, time.UTC),
+			time.Date(2024, 7, 14, 10, 3, 30, 0, time.UTC),
+			time.Date(2024, 7, 14, 10, 4, 30, 0, time.UTC),
+		}},
+		{true, []time.Time{
+			time.Date(2024, 7, 14, 10, 1, 0, 0, time.UTC),
+			time.Date(2024, 7, 14, 10, 4, 0, 0, time.UTC),
+			time.Date(2024, 7, 14, 10, 5, 0, 0, time.UTC),
+		}},
+	} {
+		clock := clocktest.NewClock(start)
+		var got []time.Time
+		task := ticker.New(func() error {
+			got = append(got, clock.Now())
+			if len(got) == 1 {
+				// The clock steps forward during the first execution.
+				clock.Set(clock.Now().Add(150 * time.Second))
+			}
+			return nil
+		})
+		done := make(chan error)
+		go func() {
+			done <- task.Run(context.Background(), time.Minute,
+				ticker.WithLimit(len(tt.want)),
+				ticker.WithClock(clock),
+				ticker.WithAlign(true),
+				ticker.WithWallClock(tt.wall),
+			)
+		}()
+		for clock.Now().Before(tt.want[len(tt.want)-1]) {
+			clock.BlockUntil(1)
+			clock.Advance(30 * time.Second)
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("WithWallClock(%v): expected %v, got %v", tt.wall, tt.want, got)
+		}
+	}
+}
+
+// TestWithAlignTolerance tests that times within the tolerance of an aligned time count as on time
+func TestWithAlignTolerance(t *testing.T) {
+	const tolerance = 10 * time.Millisecond
+	boundary := time.Date(2024, 7, 14, 10, 1, 0, 0, time.UTC)
+
+	t.Run("start", func(t *testing.T) {
+		for _, tt := range []struct {
+			tolerance  time.Duration
+			executions int32
+		}{
+			{0, 0},
+			{tolerance, 1},
+		} {
+			clock := clocktest.NewClock(boundary.Add(2 * time.Millisecond))
+			var count atomic.Int32
+			task := ticker.New(func() error {
+				count.Add(1)
+				return nil
+			})
+			h := task.Start(context.Background(), time.Minute,
+				ticker.WithClock(clock),
+				ticker.WithAlign(true),
+				ticker.WithAlignTolerance(tt.tolerance),
+			)
+			clock.BlockUntil(1)
+			if n := count.Load(); n != tt.executions {
+				t.Errorf("tolerance %v: expected %d executions at start, got %d", tt.tolerance, tt.executions, n)
+			}
+			if got, want := h.NextTick(), boundary.Add(time.Minute); !got.Equal(want) {
+				t.Errorf("tolerance %v: expected the next tick at %v, got %v", tt.tolerance, want, got)
+			}
+			h.Stop()
+		}
+	})
+
+	t.Run("early tick", func(t *testing.T) {
+		for _, tt := range []struct {
+			tolerance time.Duration
+			want      time.Time
+		}{
+			{0, boundary.Add(time.Minute)},
+			{tolerance, boundary.Add(2 * time.Minute)},
+		} {
+			clock := clocktest.NewClock(boundary.Add(-30 * time.Second))
+			executed := make(chan struct{})
+			task := ticker.New(func() error {
+				// The wall clock is stepped back, so the tick fired a little early.
+				clock.Set(boundary.Add(time.Minute - 2*time.Millisecond))
+				executed <- struct{}{}
+				return nil
+			})
+			h := task.Start(context.Background(), time.Minute,
+				ticker.WithClock(clock),
+				ticker.WithWallClock(true),
+				ticker.WithAlignTolerance(tt.tolerance),
+			)
+			clock.BlockUntil(1)
+			clock.Set(boundary)
+			<-executed
+			clock.BlockUntil(1)
+			if got := h.NextTick(); !got.Equal(tt.want) {
+				t.Errorf("tolerance %v: expected the next tick at %v, got %v", tt.tolerance, tt.want, got)
+			}
+			h.Stop()
+		}
+	})
+
+	if err := ticker.Validate(time.Minute, ticker.WithAlignTolerance(-1)); !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+// TestWithErrorChannel tests the WithErrorChannel option
+func TestWithErrorChannel(t *testing.T) {
+	task := ticker.NewIndexed(func(n int) error {
+		return fmt.Errorf("error %d", n)
+	})
+	errs := make(chan error, 2)
+	err := task.Run(context.Background(), 10*time.Millisecond,
+		ticker.WithLimit(3),
+		ticker.WithStopOnError(false),
+		ticker.WithErrorChannel(errs),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The third error is dropped as the channel is full.
+	close(errs)
+	var got []string
+	for err := range errs {
+		got = append(got, err.Error())
+	}
+	if fmt.Sprint(got) != "[error 1 error 2]" {
+		t.Errorf("expected [error 1 error 2], got %v", got)
+	}
+}
+
+// TestValidate tests that Validate reports the errors Run would return
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       time.Duration
+		options []ticker.Option
+		want    error
+	}{
+		{"valid", time.Second, []ticker.Option{ticker.WithLimit(3), ticker.WithJitter(0.1)}, nil},
+		{"interval", 0, nil, ticker.ErrNonPositiveInterval},
+		{"jitter", time.Second, []ticker.Option{ticker.WithJitter(2)}, ticker.ErrInvalidJitter},
+		{"backoff", time.Second, []ticker.Option{ticker.WithBackoff(0, time.Second, 2)}, ticker.ErrInvalidBackoff},
+		{"conflict", time.Second, []ticker.Option{ticker.WithFixedDelay(true), ticker.WithCatchUp(true)}, ticker.ErrConflictingOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ticker.Validate(tt.d, tt.options...)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected error %v, got %v", tt.want, err)
+			}
+			if tt.want != nil && !errors.Is(err, ticker.ErrInvalidArgument) {
+				t.Errorf("expected error to wrap %v, got %v", ticker.ErrInvalidArgument, err)
+			}
+
+			count := 0
+			task := ticker.New(func() error {
+				count++
+				return nil
+			})
+			if tt.want != nil {
+				if err := task.Run(context.Background(), tt.d, tt.options...); !errors.Is(err, tt.want) {
+					t.Errorf("expected Run to return %v, got %v", tt.want, err)
+				}
+				if count != 0 {
+					t.Errorf("expected no execution, got %d", count)
+				}
+			}
+		})
+	}
+}
+
+// TestWithBurst tests the WithBurst option
+func TestWithBurst(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		task := ticker.New(func() error { return nil })
+		err := task.Run(context.Background(), time.Second, ticker.WithBurst(-1))
+		if !errors.Is(err, ticker.ErrInvalidBurst) || !errors.Is(err, ticker.ErrInvalidArgument) {
+			t.Errorf("expected error %v, got %v", ticker.ErrInvalidBurst, err)
+		}
+	})
+
+	tests := []struct {
+		burst, limit int
+		want         int
+	}{
+		{burst: 3, limit: 5, want: 5},
+		{burst: 3, limit: 2, want: 2},
+		{burst: 0, limit: 2, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("burst %d limit %d", tt.burst, tt.limit), func(t *testing.T) {
+			const d = 50 * time.Millisecond
+			var times []time.Time
+			task := ticker.New(func() error {
+				times = append(times, time.Now())
+				return nil
+			})
+			start := time.Now()
+			err := task.Run(context.Background(), d, ticker.WithBurst(tt.burst), ticker.WithLimit(tt.limit))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(times) != tt.want {
+				t.Fatalf("expected %d executions, got %d", tt.want, len(times))
+			}
+			for i, at := range times {
+				immediate := at.Sub(start) < d/2
+				if immediate != (i < tt.burst) {
+					t.Errorf("execution %d: expected immediate %v, got %v after start", i, i < tt.burst, at.Sub(start))
+				}
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		ErrTask := errors.New("task error")
+		task := ticker.NewIndexed(func(n int) error {
+			if n == 2 {
+				return ErrTask
+			}
+			return nil
+		})
+		if err := task.Run(context.Background(), time.Hour, ticker.WithBurst(3)); err != ErrTask {
+			t.Errorf("expected error %v, got %v", ErrTask, err)
+		}
+	})
+}
+
+// TestWithReturnLastError tests the WithReturnLastError option
+func TestWithReturnLastError(t *testing.T) {
+	ErrTask := errors.New("task error")
+	fails := func(last int) ticker.Task {
+		return ticker.NewIndexed(func(n int) error {
+			if n == last {
+				return ErrTask
+			}
+			return nil
+		})
+	}
+	tolerant := ticker.WithStopOnError(false)
+
+	t.Run("limit", func(t *testing.T) {
+		err := fails(3).Run(context.Background(), 10*time.Millisecond,
+			ticker.WithLimit(3), tolerant, ticker.WithReturnLastError(true))
+		if err != ErrTask {
+			t.Errorf("expected error %v, got %v", ErrTask, err)
+		}
+
+		err = fails(2).Run(context.Background(), 10*time.Millisecond,
+			ticker.WithLimit(3), tolerant, ticker.WithReturnLastError(true))
+		if err != nil {
+			t.Errorf("expected nil as the last execution succeeded, got %v", err)
+		}
+
+		err = fails(3).Run(context.Background(), 10*time.Millisecond, ticker.WithLimit(3), tolerant)
+		if err != nil {
+			t.Errorf("expected nil without the option, got %v", err)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		task := ticker.NewIndexed(func(n int) error {
+			if n == 2 {
+				cancel()
+			}
+			return ErrTask
+		})
+		err := task.Run(ctx, 10*time.Millisecond, tolerant, ticker.WithReturnLastError(true))
+		if !errors.Is(err, ErrTask) || !errors.Is(err, context.Canceled) {
+			t.Errorf("expected both %v and %v, got %v", ErrTask, context.Canceled, err)
+		}
+	})
+}
+
+// TestWithTrigger tests that triggers execute the task early, at most once per interval
+func TestWithTrigger(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(start)
+	executed := make(chan time.Time)
+	task := ticker.New(func() error {
+		executed <- clock.Now()
+		return nil
+	})
+	trigger := make(chan struct{})
+	h := task.Start(context.Background(), time.Minute,
+		ticker.WithClock(clock),
+		ticker.WithFirstInterval(time.Hour),
+		ticker.WithTrigger(trigger),
+	)
+	defer h.Stop()
+
+	// The first trigger executes the task right away and restarts the schedule.
+	clock.BlockUntil(1)
+	trigger <- struct{}{}
+	if at := <-executed; !at.Equal(start) {
+		t.Errorf("expected an execution at %v, got %v", start, at)
+	}
+	clock.BlockUntil(1)
+	if got, want := h.NextTick(), start.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("expected the next tick at %v, got %v", want, got)
+	}
+
+	// A trigger within the interval is coalesced into the pending tick.
+	clock.Advance(10 * time.Second)
+	trigger <- struct{}{}
+	trigger <- struct{}{}
+	clock.BlockUntil(1)
+	clock.Advance(50 * time.Second)
+	if at, want := <-executed, start.Add(time.Minute); !at.Equal(want) {
+		t.Errorf("expected an execution at %v, got %v", want, at)
+	}
+}
+
+// TestWithLagObserver tests that WithLagObserver reports the ticks firing late
+func TestWithLagObserver(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(start)
+	task := ticker.NewIndexed(func(n int) error {
+		if n == 1 {
+			// The first execution overruns the interval.
+			clock.Advance(90 * time.Second)
+		}
+		return nil
+	})
+	var scheduled []time.Time
+	var lags []time.Duration
+	done := make(chan error)
+	go func() {
+		done <- task.Run(context.Background(), time.Minute,
+			ticker.WithClock(clock),
+			ticker.WithLimit(3),
+			ticker.WithLagObserver(func(at, actual time.Time, lag time.Duration) {
+				if actual.Sub(at) != lag {
+					t.Errorf("expected lag %v, got %v", actual.Sub(at), lag)
+				}
+				scheduled = append(scheduled, at)
+				lags = append(lags, lag)
+			}),
+		)
+	}()
+	for clock.Now().Before(start.Add(3*time.Minute + 30*time.Second)) {
+		clock.BlockUntil(1)
+		clock.Advance(30 * time.Second)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []time.Time{start.Add(time.Minute), start.Add(2 * time.Minute), start.Add(3*time.Minute + 30*time.Second)}
+	if fmt.Sprint(scheduled) != fmt.Sprint(want) {
+		t.Errorf("expected scheduled times %v, got %v", want, scheduled)
+	}
+	if fmt.Sprint(lags) != "[0s 30s 0s]" {
+		t.Errorf("expected lags [0s 30s 0s], got %v", lags)
+	}
+}
+
+// TestWithJitteredBackoff tests that the intervals follow the jitter strategies
+func TestWithJitteredBackoff(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		task := ticker.New(func() error { return nil })
+		for _, opts := range [][]ticker.Option{
+			{ticker.WithJitteredBackoff(0, time.Second, ticker.FullJitter)},
+			{ticker.WithJitteredBackoff(2*time.Second, time.Second, ticker.EqualJitter)},
+			{ticker.WithJitteredBackoff(time.Second, time.Minute, ticker.JitterStrategy(-1))},
+			{ticker.WithJitteredBackoff(time.Second, time.Minute, ticker.Decorrelated+1)},
+		} {
+			err := task.Run(context.Background(), time.Second, opts...)
+			if !errors.Is(err, ticker.ErrInvalidBackoff) {
+				t.Errorf("expected error %v, got %v", ticker.ErrInvalidBackoff, err)
+			}
+		}
+		err := task.Run(context.Background(), time.Second,
+			ticker.WithBackoff(time.Second, time.Minute, 2),
+			ticker.WithJitteredBackoff(time.Second, time.Minute, ticker.FullJitter),
+		)
+		if !errors.Is(err, ticker.ErrConflictingOptions) {
+			t.Errorf("expected error %v, got %v", ticker.ErrConflictingOptions, err)
+		}
+	})
+
+	const base, max = time.Second, 8 * time.Second
+	exp := func(attempt int) time.Duration {
+		return min(max, base<<attempt)
+	}
+	tests := []struct {
+		strategy ticker.JitterStrategy
+		bounds   func(attempt int, prev time.Duration) (lo, hi time.Duration)
+	}{
+		{ticker.FullJitter, func(attempt int, _ time.Duration) (time.Duration, time.Duration) {
+			return 0, exp(attempt)
+		}},
+		{ticker.EqualJitter, func(attempt int, _ time.Duration) (time.Duration, time.Duration) {
+			return exp(attempt) / 2, exp(attempt)
+		}},
+		{ticker.Decorrelated, func(attempt int, prev time.Duration) (time.Duration, time.Duration) {
+			if attempt == 0 {
+				prev = base
+			}
+			return base, min(max, 3*prev)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+			clock := clocktest.NewClock(start)
+			executed := make(chan struct{})
+			task := ticker.New(func() error {
+				executed <- struct{}{}
+				return errors.New("task error")
+			})
+			h := task.Start(context.Background(), time.Minute,
+				ticker.WithClock(clock),
+				ticker.WithStopOnError(false),
+				ticker.WithSeed(1),
+				ticker.WithJitteredBackoff(base, max, tt.strategy),
+			)
+			defer h.Stop()
+			clock.BlockUntil(1)
+			clock.Set(h.NextTick())
+			<-executed
+			prev := time.Duration(0)
+			for attempt := 0; attempt < 8; attempt++ {
+				clock.BlockUntil(1)
+				now, next := clock.Now(), h.NextTick()
+				iv := next.Sub(now)
+				if lo, hi := tt.bounds(attempt, prev); iv < lo || iv > hi {
+					t.Errorf("attempt %d: expected an interval within [%v, %v], got %v", attempt, lo, hi, iv)
+				}
+				prev = iv
+				clock.Set(next)
+				<-executed
+			}
+		})
+	}
+}
+
+// TestWithSemaphore tests that WithSemaphore bounds the concurrent executions
+func TestWithSemaphore(t *testing.T) {
+	sem := make(chan struct{}, 1)
+	var running, peak, count atomic.Int32
+	task := ticker.New(func() error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		count.Add(1)
+		return nil
+	})
+	err := ticker.RunAll(context.Background(),
+		ticker.Spec{Task: task, Interval: 5 * time.Millisecond, Options: []ticker.Option{ticker.WithLimit(3), ticker.WithSemaphore(sem)}},
+		ticker.Spec{Task: task, Interval: 5 * time.Millisecond, Options: []ticker.Option{ticker.WithLimit(3), ticker.WithSemaphore(sem)}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := count.Load(); n != 6 {
+		t.Errorf("expected 6 executions, got %d", n)
+	}
+	if n := peak.Load(); n != 1 {
+		t.Errorf("expected at most 1 concurrent execution, got %d", n)
+	}
+	if len(sem) != 0 {
+		t.Errorf("expected the semaphore to be released, got %d", len(sem))
+	}
+}
+
+// TestWithDecider tests that WithDecider decides whether the ticker continues
+func TestWithDecider(t *testing.T) {
+	t.Run("stop", func(t *testing.T) {
+		var decisions []ticker.Decision
+		task := ticker.NewIndexed(func(n int) error {
+			if n >= 2 {
+				return fmt.Errorf("error %d", n)
+			}
+			return nil
+		})
+		err := task.Run(context.Background(), 5*time.Millisecond,
+			ticker.WithDecider(func(d ticker.Decision) bool {
+				decisions = append(decisions, d)
+				return d.ConsecutiveErrors < 2
+			}),
+		)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(decisions) != 3 {
+			t.Fatalf("expected 3 decisions, got %d", len(decisions))
+		}
+		for i, d := range decisions {
+			if d.Index != i+1 {
+				t.Errorf("decision %d: expected index %d, got %d", i, i+1, d.Index)
+			}
+			if d.ConsecutiveErrors != i {
+				t.Errorf("decision %d: expected %d consecutive errors, got %d", i, i, d.ConsecutiveErrors)
+			}
+			if (d.Err != nil) != (i > 0) {
+				t.Errorf("decision %d: unexpected error %v", i, d.Err)
+			}
+			if i > 0 && d.Elapsed <= decisions[i-1].Elapsed {
+				t.Errorf("decision %d: expected the elapsed time to grow, got %v", i, d.Elapsed)
+			}
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		var count int
+		task := ticker.New(func() error {
+			count++
+			return nil
+		})
+		err := task.Run(context.Background(), 5*time.Millisecond,
+			ticker.WithLimit(2),
+			ticker.WithDecider(func(ticker.Decision) bool { return true }),
+		)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected 2 executions, got %d", count)
+		}
+	})
+
+	t.Run("context wins", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		task := ticker.NewContext(func(context.Context) error {
+			cancel()
+			return nil
+		})
+		err := task.Run(ctx, 5*time.Millisecond,
+			ticker.WithDecider(func(ticker.Decision) bool { return false }),
+		)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+// TestWithSpin tests that WithSpin allows a zero interval and stops promptly on cancel
+func TestWithSpin(t *testing.T) {
+	task := ticker.New(func() error { return nil })
+	if err := task.Run(context.Background(), 0); !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected ErrNonPositiveInterval without WithSpin, got %v", err)
+	}
+	if err := task.Run(context.Background(), -1, ticker.WithSpin(true)); !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected ErrNonPositiveInterval for a negative interval, got %v", err)
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		var count int
+		task := ticker.New(func() error {
+			count++
+			return nil
+		})
+		err := task.Run(context.Background(), 0, ticker.WithSpin(true), ticker.WithLimit(1000))
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if count != 1000 {
+			t.Errorf("expected 1000 executions, got %d", count)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var count atomic.Int64
+		task := ticker.New(func() error {
+			count.Add(1)
+			return nil
+		})
+		done := make(chan error, 1)
+		go func() { done <- task.Run(ctx, 0, ticker.WithSpin(true)) }()
+		for count.Load() < 100 {
+			runtime.Gosched()
+		}
+		cancel()
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context.Canceled, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the ticker to stop promptly")
+		}
+	})
+}
+
+// TestWithPreTick tests that WithPreTick aborts the ticker before an execution
+func TestWithPreTick(t *testing.T) {
+	errQuota := errors.New("quota exhausted")
+	var checked []int
+	var count int
+	task := ticker.New(func() error {
+		count++
+		return nil
+	})
+	err := task.Run(context.Background(), 5*time.Millisecond,
+		ticker.WithStopOnError(false),
+		ticker.WithPreTick(func(n int) error {
+			checked = append(checked, n)
+			if n == 3 {
+				return errQuota
+			}
+			return nil
+		}),
+	)
+	if !errors.Is(err, ticker.ErrPreTickAbort) || !errors.Is(err, errQuota) {
+		t.Errorf("expected ErrPreTickAbort wrapping the check error, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 executions, got %d", count)
+	}
+	if got := fmt.Sprint(checked); got != "[1 2 3]" {
+		t.Errorf("expected checks [1 2 3], got %s", got)
+	}
+}
+
+// TestWithStopSignal tests that WithStopSignal stops the ticker with ErrStopped
+func TestWithStopSignal(t *testing.T) {
+	t.Run("signal", func(t *testing.T) {
+		sig := make(chan struct{}, 1)
+		task := ticker.NewIndexed(func(n int) error {
+			if n == 2 {
+				sig <- struct{}{}
+			}
+			return nil
+		})
+		err := task.Run(context.Background(), 5*time.Millisecond, ticker.WithStopSignal(sig))
+		if !errors.Is(err, ticker.ErrStopped) {
+			t.Errorf("expected ErrStopped, got %v", err)
+		}
+	})
+
+	t.Run("race with a tick", func(t *testing.T) {
+		const d = time.Minute
+		clock := clocktest.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		sig := make(chan struct{})
+		started, release := make(chan struct{}), make(chan struct{})
+		var count int
+		task := ticker.New(func() error {
+			count++
+			started <- struct{}{}
+			<-release
+			return nil
+		})
+		done := make(chan error, 1)
+		go func() { done <- task.Run(context.Background(), d, ticker.WithClock(clock), ticker.WithStopSignal(sig)) }()
+		clock.BlockUntil(1)
+		clock.Advance(d)
+		<-started
+		// The next tick is already due when the execution completes, together with
+		// the signal.
+		clock.Advance(2 * d)
+		close(sig)
+		close(release)
+		if err := <-done; !errors.Is(err, ticker.ErrStopped) {
+			t.Errorf("expected ErrStopped, got %v", err)
+		}
+		if count != 1 {
+			t.Errorf("expected 1 execution, got %d", count)
+		}
+	})
+}
+
+// TestWithMinInterval tests that WithMinInterval raises the computed intervals
+func TestWithMinInterval(t *testing.T) {
+	if err := ticker.Validate(time.Second, ticker.WithMinInterval(0)); !errors.Is(err, ticker.ErrNonPositiveInterval) {
+		t.Errorf("expected ErrNonPositiveInterval, got %v", err)
+	}
+
+	const floor = 10 * time.Second
+	tests := []struct {
+		name    string
+		options []ticker.Option
+	}{
+		{"Interval", []ticker.Option{ticker.WithInterval(func(error) time.Duration { return time.Nanosecond })}},
+		{"Backoff", []ticker.Option{ticker.WithBackoff(time.Millisecond, 20*time.Second, 2)}},
+		{"JitteredBackoff", []ticker.Option{ticker.WithJitteredBackoff(time.Millisecond, 20*time.Second, ticker.FullJitter)}},
+		{"Jitter", []ticker.Option{ticker.WithJitter(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+			clock := clocktest.NewClock(start)
+			executed := make(chan struct{})
+			task := ticker.New(func() error {
+				executed <- struct{}{}
+				return errors.New("task error")
+			})
+			options := append(tt.options,
+				ticker.WithClock(clock),
+				ticker.WithStopOnError(false),
+				ticker.WithSeed(1),
+				ticker.WithMinInterval(floor),
+			)
+			h := task.Start(context.Background(), floor, options...)
+			defer h.Stop()
+			for i := 0; i < 8; i++ {
+				clock.BlockUntil(1)
+				now, next := clock.Now(), h.NextTick()
+				if iv := next.Sub(now); iv < floor {
+					t.Errorf("tick %d: expected an interval of at least %v, got %v", i, floor, iv)
+				}
+				clock.Set(next)
+				<-executed
+			}
+		})
+	}
+}
+
+// TestWithAsync tests that WithAsync overlaps the executions and stops on their errors
+func TestWithAsync(t *testing.T) {
+	t.Run("overlap", func(t *testing.T) {
+		var running, peak atomic.Int32
+		task := ticker.New(func() error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(30 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+		h := task.Start(context.Background(), 5*time.Millisecond, ticker.WithAsync(true), ticker.WithLimit(4))
+		if err := h.Drain(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n := peak.Load(); n < 2 {
+			t.Errorf("expected overlapping executions, got a peak of %d", n)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errTask := errors.New("task error")
+		task := ticker.NewIndexed(func(n int) error {
+			if n == 3 {
+				return errTask
+			}
+			return nil
+		})
+		err := task.Run(context.Background(), 5*time.Millisecond, ticker.WithAsync(true))
+		if !errors.Is(err, errTask) {
+			t.Errorf("expected error %v, got %v", errTask, err)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		err := ticker.Validate(time.Second, ticker.WithAsync(true), ticker.WithSkipIfRunning(true))
+		if !errors.Is(err, ticker.ErrConflictingOptions) {
+			t.Errorf("expected error %v, got %v", ticker.ErrConflictingOptions, err)
+		}
+	})
+}
+
+// TestWithStartDelay tests that WithStartDelay delays the immediate execution and can be canceled
+func TestWithStartDelay(t *testing.T) {
+	t.Run("immediate", func(t *testing.T) {
+		clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+		var count atomic.Int32
+		task := ticker.New(func() error {
+			count.Add(1)
+			return nil
+		})
+		h := task.Start(context.Background(), time.Minute,
+			ticker.WithClock(clock),
+			ticker.WithImmediate(true),
+			ticker.WithStartDelay(30*time.Second),
+		)
+		defer h.Stop()
+		clock.BlockUntil(1)
+		if n := count.Load(); n != 0 {
+			t.Errorf("expected no execution during the start delay, got %d", n)
+		}
+		clock.Advance(30 * time.Second)
+		clock.BlockUntil(1)
+		if n := count.Load(); n != 1 {
+			t.Errorf("expected the immediate execution after the start delay, got %d", n)
+		}
+		if got, want := h.NextTick(), clock.Now().Add(time.Minute); !got.Equal(want) {
+			t.Errorf("expected the first tick at %v, got %v", want, got)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			return nil
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		err := task.Run(ctx, time.Millisecond, ticker.WithImmediate(true), ticker.WithStartDelay(time.Hour))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if count != 0 {
+			t.Errorf("expected no execution, got %d", count)
+		}
+	})
+
+	if err := ticker.Validate(time.Second, ticker.WithStartDelay(-1)); !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+// TestWithTotalExecBudget tests that WithTotalExecBudget stops once the executions exceed the budget
+func TestWithTotalExecBudget(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options []ticker.Option
+		want    int
+	}{
+		{"budget", nil, 3},
+		{"limit first", []ticker.Option{ticker.WithLimit(2)}, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+			count := 0
+			task := ticker.New(func() error {
+				count++
+				clock.Advance(10 * time.Second)
+				return nil
+			})
+			options := append(tt.options,
+				ticker.WithClock(clock),
+				ticker.WithBurst(10),
+				ticker.WithTotalExecBudget(25*time.Second),
+			)
+			if err := task.Run(context.Background(), time.Hour, options...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count != tt.want {
+				t.Errorf("expected %d executions, got %d", tt.want, count)
+			}
+		})
+	}
+}
+
+// TestWithAdaptiveToLatency tests that the interval follows the duration of the executions
+func TestWithAdaptiveToLatency(t *testing.T) {
+	for _, opts := range [][]ticker.Option{
+		{ticker.WithAdaptiveToLatency(0, time.Second, time.Minute)},
+		{ticker.WithAdaptiveToLatency(5, 0, time.Minute)},
+		{ticker.WithAdaptiveToLatency(5, time.Minute, time.Second)},
+	} {
+		if err := ticker.Validate(time.Second, opts...); !errors.Is(err, ticker.ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	}
+	err := ticker.Validate(time.Second,
+		ticker.WithAdaptiveToLatency(5, time.Second, time.Minute),
+		ticker.WithBackoff(time.Second, time.Minute, 2),
+	)
+	if !errors.Is(err, ticker.ErrConflictingOptions) {
+		t.Errorf("expected ErrConflictingOptions, got %v", err)
+	}
+
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	durations := []time.Duration{2 * time.Second, 100 * time.Millisecond, 30 * time.Second, 4 * time.Second}
+	want := []time.Duration{10 * time.Second, time.Second, time.Minute, 20 * time.Second}
+	executed := make(chan struct{})
+	task := ticker.NewIndexed(func(n int) error {
+		clock.Advance(durations[n-1])
+		executed <- struct{}{}
+		return nil
+	})
+	h := task.Start(context.Background(), 5*time.Second,
+		ticker.WithClock(clock),
+		ticker.WithAdaptiveToLatency(5, time.Second, time.Minute),
+	)
+	defer h.Stop()
+	for i := range durations {
+		clock.BlockUntil(1)
+		clock.Set(h.NextTick())
+		start := clock.Now()
+		<-executed
+		clock.BlockUntil(1)
+		if got := h.NextTick().Sub(start); got != want[i] {
+			t.Errorf("execution %d: expected an interval of %v, got %v", i+1, want[i], got)
+		}
+	}
+}
+
+// TestWithContextErrorAsNil tests that the end of the context is a clean stop
+func TestWithContextErrorAsNil(t *testing.T) {
+	task := ticker.New(func() error { return nil })
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+		}()
+		if err := task.Run(ctx, 5*time.Millisecond, ticker.WithContextErrorAsNil(true)); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := task.Run(ctx, 5*time.Millisecond,
+			ticker.WithContextErrorAsNil(true),
+			ticker.WithDeadlineError(errors.New("window closed")),
+		)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("task error", func(t *testing.T) {
+		errTask := errors.New("task error")
+		ctx, cancel := context.WithCancel(context.Background())
+		task := ticker.New(func() error {
+			cancel()
+			return errTask
+		})
+		err := task.Run(ctx, 5*time.Millisecond, ticker.WithContextErrorAsNil(true))
+		if err != errTask {
+			t.Errorf("expected error %v alone, got %v", errTask, err)
+		}
+	})
+}
+
+// TestWithGracePeriod tests that errors are ignored only within the grace period
+func TestWithGracePeriod(t *testing.T) {
+	ErrTask := errors.New("task error")
+	clock := clocktest.NewClock(time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC))
+	task := ticker.New(func() error {
+		clock.Advance(time.Second)
+		return ErrTask
+	})
+	stats, err := task.RunStats(context.Background(), time.Hour,
+		ticker.WithClock(clock),
+		ticker.WithBurst(10),
+		ticker.WithGracePeriod(2500*time.Millisecond),
+	)
+	if !errors.Is(err, ErrTask) || stats.Executions != 4 || stats.Errors != 4 {
+		t.Errorf("expected %v after 4 failed executions, got %v after %d with %d errors",
+			ErrTask, err, stats.Executions, stats.Errors)
+	}
+
+	err = ticker.Validate(time.Second, ticker.WithGracePeriod(time.Second), ticker.WithFailFastFirst(true))
+	if !errors.Is(err, ticker.ErrConflictingOptions) {
+		t.Errorf("expected ErrConflictingOptions, got %v", err)
+	}
+	if err := ticker.Validate(time.Second, ticker.WithGracePeriod(-time.Second)); !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+// TestWithActiveWindow tests that the task is executed only within the window
+func TestWithActiveWindow(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	for _, tt := range []struct {
+		name       string
+		start, end time.Duration
+		loc        *time.Location
+		at         time.Time
+		want       string
+	}{
+		{
+			"boundaries", 9 * time.Hour, 11 * time.Hour, nil,
+			time.Date(2024, 7, 14, 8, 0, 0, 0, time.UTC),
+			"[09:00 09:30 10:00 10:30]",
+		},
+		{
+			"past midnight", 23 * time.Hour, time.Hour, nil,
+			time.Date(2024, 7, 14, 22, 0, 0, 0, time.UTC),
+			"[23:00 23:30 00:00 00:30]",
+		},
+		{
+			"location", 9 * time.Hour, 10 * time.Hour, time.FixedZone("UTC+9", 9*60*60),
+			time.Date(2024, 7, 14, 23, 0, 0, 0, time.UTC),
+			"[09:00 09:30]",
+		},
+		{
+			// 02:00 EST is followed by 03:00 EDT.
+			"spring forward", 2*time.Hour + 30*time.Minute, 4 * time.Hour, newYork,
+			time.Date(2024, 3, 10, 0, 0, 0, 0, newYork),
+			"[03:00 03:30]",
+		},
+		{
+			// 02:00 EDT is followed by 01:00 EST.
+			"fall back", time.Hour, time.Hour + 30*time.Minute, newYork,
+			time.Date(2024, 11, 3, 0, 0, 0, 0, newYork),
+			"[01:00 01:00]",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := clocktest.NewClock(tt.at)
+			var got []string
+			task := ticker.New(func() error {
+				now := clock.Now()
+				if tt.loc != nil {
+					now = now.In(tt.loc)
+				}
+				got = append(got, now.Format("15:04"))
+				return nil
+			})
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- task.Run(ctx, 30*time.Minute,
+					ticker.WithClock(clock),
+					ticker.WithImmediate(true),
+					ticker.WithActiveWindow(tt.start, tt.end, tt.loc),
+				)
+			}()
+			for i := 0; i < 8; i++ {
+				clock.BlockUntil(1)
+				clock.Advance(30 * time.Minute)
+			}
+			clock.BlockUntil(1)
+			cancel()
+			<-done
+			if s := fmt.Sprint(got); s != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, s)
+			}
+		})
+	}
+
+	for _, w := range [][2]time.Duration{{time.Hour, time.Hour}, {-time.Hour, time.Hour}, {time.Hour, 24 * time.Hour}} {
+		if err := ticker.Validate(time.Second, ticker.WithActiveWindow(w[0], w[1], nil)); !errors.Is(err, ticker.ErrInvalidArgument) {
+			t.Errorf("%v: expected ErrInvalidArgument, got %v", w, err)
+		}
+	}
+}
+
+// TestWithErrorBackoff tests that errors delay the next execution while successful executions keep the base interval
+func TestWithErrorBackoff(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(start)
+	fail := map[int]bool{2: true, 3: true, 4: true}
+	var got []time.Duration
+	task := ticker.NewIndexed(func(n int) error {
+		got = append(got, clock.Now().Sub(start))
+		if fail[n] {
+			return errors.New("task error")
+		}
+		return nil
+	})
+	done := make(chan error, 1)
+	go func() {
+		done <- task.Run(context.Background(), time.Second,
+			ticker.WithClock(clock),
+			ticker.WithLimit(7),
+			ticker.WithStopOnError(false),
+			ticker.WithErrorBackoff(3*time.Second),
+		)
+	}()
+	for i := 0; i < 12; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "[1s 2s 4s 7s 10s 11s 12s]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("expected executions at %s, got %v", want, got)
+	}
+
+	for _, opts := range [][]ticker.Option{
+		{ticker.WithErrorBackoff(-time.Second)},
+		{ticker.WithErrorBackoff(time.Second), ticker.WithBackoff(time.Second, time.Minute, 2)},
+	} {
+		if err := ticker.Validate(time.Second, opts...); !errors.Is(err, ticker.ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	}
+}
+
+// BenchmarkRun measures the steady loop of Run, where each tick should not allocate.
+func BenchmarkRun(b *testing.B) {
+	task := ticker.New(func() error { return nil })
+	b.ReportAllocs()
+	b.ResetTimer()
+	if err := task.Run(context.Background(), time.Nanosecond, ticker.WithLimit(b.N)); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// taggedContext is a context of a type that is not comparable.
+type taggedContext struct {
+	context.Context
+	tags []string
+}
+
+// TestRun_UncomparableContext tests that Run accepts a context of a type that is not
+// comparable
+func TestRun_UncomparableContext(t *testing.T) {
+	ctx := taggedContext{Context: context.Background(), tags: []string{"a"}}
+	var n int
+	task := ticker.New(func() error { n++; return nil })
+	if err := task.Run(ctx, time.Millisecond, ticker.WithLimit(3)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 executions, got %d", n)
+	}
+}
+
+// TestWithOnLimitReached tests that the callback is called only when the limit stops the ticker
+func TestWithOnLimitReached(t *testing.T) {
+	ErrTask := errors.New("task error")
+	for _, tt := range []struct {
+		name  string
+		limit int
+		fail  int
+		stop  bool
+		want  int
+	}{
+		{"limit", 3, 0, false, 3},
+		{"error", 3, 2, false, -1},
+		{"error on last", 3, 3, false, -1},
+		{"stopped", 100, 0, true, -1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stop := make(chan struct{})
+			task := ticker.NewIndexed(func(n int) error {
+				if n == tt.fail {
+					return ErrTask
+				}
+				if tt.stop && n == 2 {
+					close(stop)
+				}
+				return nil
+			})
+			got := -1
+			err := task.Run(context.Background(), time.Millisecond,
+				ticker.WithLimit(tt.limit),
+				ticker.WithStopChan(stop),
+				ticker.WithOnLimitReached(func(total int) { got = total }),
+			)
+			if tt.fail > 0 && !errors.Is(err, ErrTask) || tt.fail == 0 && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		called := false
+		task := ticker.New(func() error { cancel(); return nil })
+		err := task.Run(ctx, time.Millisecond,
+			ticker.WithLimit(3),
+			ticker.WithOnLimitReached(func(int) { called = true }),
+		)
+		if !errors.Is(err, context.Canceled) || called {
+			t.Errorf("expected context.Canceled without the callback, got %v and %v", err, called)
+		}
+	})
+}
+
+// TestWithOnLimitReached_Async tests that the callback waits for the executions of
+// WithAsync in flight
+func TestWithOnLimitReached_Async(t *testing.T) {
+	var completed atomic.Int32
+	task := ticker.New(func() error {
+		time.Sleep(20 * time.Millisecond)
+		completed.Add(1)
+		return nil
+	})
+	got := -1
+	err := task.Run(context.Background(), time.Millisecond,
+		ticker.WithAsync(true),
+		ticker.WithLimit(3),
+		ticker.WithOnLimitReached(func(total int) {
+			if n := completed.Load(); n != 3 {
+				t.Errorf("expected 3 completed executions, got %d", n)
+			}
+			got = total
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 3 {
+		t.Errorf("expected a total of 3, got %d", got)
+	}
+}
+
+// TestWithShutdownTimeout tests that a stuck execution is abandoned once a stop is requested
+func TestWithShutdownTimeout(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options []ticker.Option
+		stuck   int
+	}{
+		{"execution", nil, 1},
+		{"skip if running", []ticker.Option{ticker.WithSkipIfRunning(true)}, 1},
+		// The first execution returns normally, and the final one gets stuck.
+		{"final tick", []ticker.Option{ticker.WithFinalTick(true)}, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+			ctx, cancel := context.WithCancel(context.Background())
+			stuck := tt.stuck
+			task := ticker.NewIndexed(func(n int) error {
+				cancel()
+				if n == stuck {
+					<-release
+				}
+				return nil
+			})
+			options := append(tt.options, ticker.WithShutdownTimeout(10*time.Millisecond))
+			err := task.Run(ctx, time.Millisecond, options...)
+			if !errors.Is(err, ticker.ErrShutdownTimeout) || !errors.Is(err, context.Canceled) {
+				t.Errorf("expected ErrShutdownTimeout and context.Canceled, got %v", err)
+			}
+		})
+	}
+
+	if err := ticker.Validate(time.Second, ticker.WithShutdownTimeout(-time.Second)); !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+// TestWithInitialJitter tests that only the first tick is delayed, reproducibly with WithSeed
+func TestWithInitialJitter(t *testing.T) {
+	start := time.Date(2024, 7, 14, 10, 0, 0, 0, time.UTC)
+	// firstTicks returns the times of the first two ticks of a ticker seeded with seed.
+	firstTicks := func(seed int64) (time.Time, time.Time) {
+		clock := clocktest.NewClock(start)
+		h := ticker.New(func() error { return nil }).Start(context.Background(), time.Minute,
+			ticker.WithClock(clock),
+			ticker.WithInitialJitter(30*time.Second),
+			ticker.WithSeed(seed),
+		)
+		defer h.Stop()
+		clock.BlockUntil(1)
+		first := h.NextTick()
+		clock.Set(first)
+		for h.Stats().Executions == 0 {
+			runtime.Gosched()
+		}
+		clock.BlockUntil(1)
+		return first, h.NextTick()
+	}
+
+	first, second := firstTicks(1)
+	if delay := first.Sub(start); delay < time.Minute || delay >= time.Minute+30*time.Second {
+		t.Errorf("expected the first tick within [1m, 1m30s), got %v", delay)
+	}
+	if iv := second.Sub(first); iv != time.Minute {
+		t.Errorf("expected the second tick 1m after the first, got %v", iv)
+	}
+	if again, _ := firstTicks(1); !again.Equal(first) {
+		t.Errorf("expected the same first tick with the same seed, got %v and %v", first, again)
+	}
+
+	if err := ticker.Validate(time.Second, ticker.WithInitialJitter(-time.Second)); !errors.Is(err, ticker.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestWithLockOSThread tests that every execution runs on the same OS thread
+func TestWithLockOSThread(t *testing.T) {
+	tids := map[int]bool{}
+	task := ticker.New(func() error {
+		tids[syscall.Gettid()] = true
+		runtime.Gosched()
+		return nil
+	})
+	// Busy goroutines make an unlocked goroutine likely to resume on another thread.
+	stop := make(chan struct{})
+	defer close(stop)
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	err := task.Run(context.Background(), time.Millisecond,
+		ticker.WithImmediate(true),
+		ticker.WithLimit(20),
+		ticker.WithLockOSThread(true),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tids) != 1 {
+		t.Errorf("expected a single thread, got %d", len(tids))
+	}
+
+	err = ticker.Validate(time.Second, ticker.WithLockOSThread(true), ticker.WithAsync(true))
+	if !errors.Is(err, ticker.ErrConflictingOptions) {
+		t.Errorf("expected ErrConflictingOptions, got %v", err)
+	}
+}
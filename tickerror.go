@@ -0,0 +1,44 @@
+package ticker
+
+import (
+	"fmt"
+	"time"
+)
+
+// TickError is the error returned by Run when an execution fails, if enabled by
+// WithTickError. It reports which execution produced the error.
+type TickError struct {
+	// Index is the 1-based execution counter, as seen by NewIndexed.
+	Index int
+
+	// At is the time the execution started.
+	At time.Time
+
+	// Err is the error of the execution.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TickError) Error() string {
+	return fmt.Sprintf("tick %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the execution, so that errors.Is and errors.As match it.
+func (e *TickError) Unwrap() error {
+	return e.Err
+}
+
+// WithTickError returns an Option to wrap the error of a failed execution in a
+// *TickError, which reports the execution counter and start time.
+//
+// It is disabled by default to preserve the identity of the errors returned by Run.
+// Errors that do not come from an execution, such as context errors, are not wrapped.
+func WithTickError(enabled bool) Option {
+	return tickError(enabled)
+}
+
+type tickError bool
+
+func (o tickError) apply(c *config) {
+	c.TickError = bool(o)
+}
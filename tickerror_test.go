@@ -0,0 +1,49 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestWithTickError tests that WithTickError reports the failed execution
+func TestWithTickError(t *testing.T) {
+	errTask := errors.New("task error")
+	task := ticker.NewIndexed(func(n int) error {
+		if n == 3 {
+			return errTask
+		}
+		return nil
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		before := time.Now()
+		err := task.Run(context.Background(), 10*time.Millisecond, ticker.WithTickError(true))
+		var te *ticker.TickError
+		if !errors.As(err, &te) {
+			t.Fatalf("expected *TickError, got %v", err)
+		}
+		if te.Index != 3 {
+			t.Errorf("expected index 3, got %d", te.Index)
+		}
+		if te.At.Before(before) || te.At.After(time.Now()) {
+			t.Errorf("unexpected start time %v", te.At)
+		}
+		if !errors.Is(err, errTask) {
+			t.Errorf("expected task error, got %v", err)
+		}
+		if want := "tick 3: task error"; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		err := task.Run(context.Background(), 10*time.Millisecond)
+		if err != errTask {
+			t.Errorf("expected the task error itself, got %v", err)
+		}
+	})
+}
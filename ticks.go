@@ -0,0 +1,61 @@
+//go:build go1.23
+
+package ticker
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// Ticks returns a sequence of the ticks of a ticker, for use with a range loop:
+//
+//	for i, t := range ticker.Ticks(ctx, time.Second) {
+//		fmt.Println(i, t)
+//	}
+//
+// Each iteration yields the 1-based execution counter and the time of the tick, and
+// the loop body runs in place of the task. The sequence ends when the loop breaks, the
+// context is canceled, or the ticker stops for any other reason, such as WithLimit or
+// WithMaxDuration. It is empty if the arguments are invalid.
+//
+// Options apply as for Run, including WithImmediate and WithLimit. Options about task
+// errors and panics, such as WithOnError, WithRetry, WithRecover and WithFinalTick, have
+// no effect, and neither do the middlewares of WithMiddleware, so that a panic in the
+// loop body reaches the caller. The ticks are always synchronous on the goroutine of the
+// loop:
+// WithSkipIfRunning, WithAsync and WithShutdownTimeout have no effect either. Breaking
+// the loop stops the ticker cleanly; it is not reported as an error to the options
+// that observe the executions, such as WithErrorChannel, WithLogger and WithObservers.
+func Ticks(ctx context.Context, d time.Duration, options ...Option) iter.Seq2[int, time.Time] {
+	return func(yield func(int, time.Time) bool) {
+		var s *session
+		// done guards yield once the loop has broken, in case an option lets the
+		// ticker execute the task again before it stops.
+		var done bool
+		task := func(ctx context.Context) error {
+			if done {
+				return nil
+			}
+			info, _ := tickFromContext(ctx)
+			if !yield(info.n, s.c.Clock.Now()) {
+				done = true
+				s.halt()
+			}
+			return nil
+		}
+		s, err := newSession(task, d, options)
+		if err != nil {
+			return
+		}
+		// The loop body is not wrapped in the middlewares, which could recover its
+		// panics or call it on another goroutine.
+		s.task = task
+		c := s.c
+		c.OnError, c.Retry, c.Recover, c.PanicAsError = nil, nil, nil, false
+		c.StopOnError, c.FinalTick, c.SkipIfRunning = true, false, false
+		// yield must be called on the goroutine of the range loop.
+		c.Async, c.Shutdown = false, 0
+		s.run(ctx)
+	}
+}
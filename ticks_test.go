@@ -0,0 +1,105 @@
+//go:build go1.23
+
+package ticker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestTicks tests that Ticks yields the ticks to a range loop
+func TestTicks(t *testing.T) {
+	t.Run("limit", func(t *testing.T) {
+		start := time.Now()
+		var got []int
+		for i, at := range ticker.Ticks(context.Background(), 10*time.Millisecond,
+			ticker.WithImmediate(true),
+			ticker.WithLimit(3),
+		) {
+			if at.Before(start) {
+				t.Errorf("tick %d: unexpected time %v", i, at)
+			}
+			got = append(got, i)
+		}
+		if len(got) != 3 || got[0] != 1 || got[2] != 3 {
+			t.Errorf("expected ticks [1 2 3], got %v", got)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var got []int
+		for i := range ticker.Ticks(context.Background(), 10*time.Millisecond) {
+			got = append(got, i)
+			if i == 2 {
+				break
+			}
+		}
+		if len(got) != 2 {
+			t.Errorf("expected 2 ticks, got %v", got)
+		}
+	})
+
+	t.Run("break with options", func(t *testing.T) {
+		for name, option := range map[string]ticker.Option{
+			"grace period":     ticker.WithGracePeriod(time.Second),
+			"decider":          ticker.WithDecider(func(ticker.Decision) bool { return true }),
+			"async":            ticker.WithAsync(true),
+			"shutdown timeout": ticker.WithShutdownTimeout(time.Second),
+		} {
+			errc := make(chan error, 10)
+			count := 0
+			for range ticker.Ticks(context.Background(), 10*time.Millisecond,
+				ticker.WithBurst(5),
+				ticker.WithErrorChannel(errc),
+				option,
+			) {
+				count++
+				break
+			}
+			if count != 1 {
+				t.Errorf("%s: expected 1 tick, got %d", name, count)
+			}
+			select {
+			case err := <-errc:
+				t.Errorf("%s: expected no error for the break, got %v", name, err)
+			default:
+			}
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 35*time.Millisecond)
+		defer cancel()
+		count := 0
+		for range ticker.Ticks(ctx, 10*time.Millisecond) {
+			count++
+		}
+		if count < 2 || count > 3 {
+			t.Errorf("expected about 3 ticks, got %d", count)
+		}
+		if ctx.Err() == nil {
+			t.Error("expected the sequence to end with the context")
+		}
+	})
+
+	t.Run("panic with middleware", func(t *testing.T) {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("expected the panic of the loop body, got %v", v)
+			}
+		}()
+		for range ticker.Ticks(context.Background(), time.Millisecond, ticker.WithMiddleware(ticker.Recover())) {
+			panic("boom")
+		}
+		t.Error("expected a panic")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for range ticker.Ticks(context.Background(), 0) {
+			t.Fatal("expected an empty sequence")
+		}
+	})
+}
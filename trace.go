@@ -0,0 +1,35 @@
+package ticker
+
+import (
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// WithTraceRegion returns an Option to annotate each execution for profiling.
+//
+// Each execution runs in a runtime/trace region named name, so that it shows up in
+// execution traces, and with the pprof label ticker=name, so that CPU profiles can be
+// filtered by ticker. The labels are also set on the context passed to the task, where
+// pprof.Label can read them. An empty name disables the annotation, which is the default.
+func WithTraceRegion(name string) Option {
+	return traceRegion(name)
+}
+
+type traceRegion string
+
+func (o traceRegion) apply(c *config) {
+	c.TraceRegion = string(o)
+}
+
+// traced executes the task within the trace region and pprof labels set by
+// WithTraceRegion.
+func (s *session) traced(ctx context.Context) (err error) {
+	name := s.c.TraceRegion
+	pprof.Do(ctx, pprof.Labels("ticker", name), func(ctx context.Context) {
+		trace.WithRegion(ctx, name, func() {
+			err = s.task(ctx)
+		})
+	})
+	return err
+}
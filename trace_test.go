@@ -0,0 +1,40 @@
+package ticker_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestWithTraceRegion tests that WithTraceRegion sets the pprof label during each execution
+func TestWithTraceRegion(t *testing.T) {
+	var labels []string
+	task := ticker.NewContext(func(ctx context.Context) error {
+		v, ok := pprof.Label(ctx, "ticker")
+		if !ok {
+			v = "<none>"
+		}
+		labels = append(labels, v)
+		return nil
+	})
+
+	err := task.Run(context.Background(), 5*time.Millisecond, ticker.WithLimit(2), ticker.WithTraceRegion("poller"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != 2 || labels[0] != "poller" || labels[1] != "poller" {
+		t.Errorf("expected the label ticker=poller on each execution, got %v", labels)
+	}
+
+	labels = nil
+	err = task.Run(context.Background(), 5*time.Millisecond, ticker.WithLimit(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "<none>" {
+		t.Errorf("expected no label without WithTraceRegion, got %v", labels)
+	}
+}
@@ -0,0 +1,55 @@
+package ticker
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Until executes the task periodically until it succeeds once, and then returns nil.
+//
+// This inverts the error semantics of Run: a task error does not stop the ticker, but
+// a successful execution does. Until also stops when the context is canceled, returning
+// the context error. WithLimit serves as a maximum number of attempts; if the limit is
+// reached without success, Until returns the last task error.
+//
+// Failed attempts are passed to the WithOnError callback, if any, which can still abort
+// by returning a non-nil error. WithStopOnError has no effect.
+func (task Task) Until(ctx context.Context, d time.Duration, options ...Option) error {
+	s, err := newSession(task, d, options)
+	if err != nil {
+		return err
+	}
+	s.until = true
+	return s.run(ctx)
+}
+
+// errSucceeded stops the ticker of Until after a successful execution.
+var errSucceeded = errors.New("ticker: succeeded")
+
+// untilNext decides whether Until continues after an execution that returned err.
+func (s *session) untilNext(err error) error {
+	if err == nil {
+		return errSucceeded
+	}
+	s.mu.Lock()
+	s.lastErr = err
+	s.mu.Unlock()
+	if s.c.OnError != nil {
+		return s.c.OnError(err)
+	}
+	return nil
+}
+
+// untilResult translates the result of run for Until.
+func (s *session) untilResult(err error) error {
+	if errors.Is(err, errSucceeded) {
+		return nil
+	}
+	if err == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lastErr
+	}
+	return err
+}
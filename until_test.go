@@ -0,0 +1,65 @@
+package ticker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goaux/ticker"
+)
+
+// TestTask_Until tests the Until method
+func TestTask_Until(t *testing.T) {
+	ErrTask := errors.New("task error")
+
+	t.Run("Success", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			if count < 3 {
+				return ErrTask
+			}
+			return nil
+		})
+		err := task.Until(context.Background(), 10*time.Millisecond, ticker.WithImmediate(true))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if count != 3 {
+			t.Errorf("expected 3 attempts, got %d", count)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		count := 0
+		task := ticker.New(func() error {
+			count++
+			return ErrTask
+		})
+		var stopped error
+		err := task.Until(context.Background(), 10*time.Millisecond,
+			ticker.WithLimit(3),
+			ticker.WithOnStop(func(err error) { stopped = err }),
+		)
+		if !errors.Is(err, ErrTask) {
+			t.Errorf("expected error %v, got %v", ErrTask, err)
+		}
+		if !errors.Is(stopped, ErrTask) {
+			t.Errorf("expected OnStop to receive %v, got %v", ErrTask, stopped)
+		}
+		if count != 3 {
+			t.Errorf("expected 3 attempts, got %d", count)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		task := ticker.New(func() error { return ErrTask })
+		err := task.Until(ctx, 10*time.Millisecond)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+		}
+	})
+}